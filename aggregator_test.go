@@ -0,0 +1,104 @@
+package database_test
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/gomig/database/v2"
+	"github.com/gomig/database/v2/types"
+)
+
+func TestAggregator(t *testing.T) {
+	var rows [][]driver.Value
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"value"}, rows
+	})
+	query := database.NewQuery().AndIn("status", "paid", "sent").AndGt("total", 10)
+
+	rows = [][]driver.Value{{float64(25.5)}}
+	total, err := database.NewAggregator[float64](db).
+		Table("orders").
+		Where(query).
+		Sum("total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sumExp := `SELECT SUM(total) FROM orders WHERE status IN ($1, $2) AND total > $3;`
+	if sql, args := fake.last(); sql != sumExp || !reflect.DeepEqual(args, []any{"paid", "sent", int64(10)}) || total != 25.5 {
+		t.Logf("Expected: %s\nReturns: %s %v %v\n", sumExp, sql, args, total)
+		t.Error("Sum() failed")
+	}
+
+	rows = [][]driver.Value{{nil}}
+	avg, err := database.NewAggregator[types.Null[float64]](db).
+		NumericArgs(false).
+		Table("orders").
+		Where(query).
+		Avg("total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	avgExp := `SELECT AVG(total) FROM orders WHERE status IN (?, ?) AND total > ?;`
+	if sql, _ := fake.last(); sql != avgExp || avg.Valid {
+		t.Logf("Expected: %s\nReturns: %s %v\n", avgExp, sql, avg)
+		t.Error("Avg() failed")
+	}
+
+	rows = [][]driver.Value{{int64(7)}}
+	database.NewAggregator[int64](db).Table("orders").Count("*")
+	countExp := `SELECT COUNT(*) FROM orders;`
+	if sql, _ := fake.last(); sql != countExp {
+		t.Logf("Expected: %s\nReturns: %s\n", countExp, sql)
+		t.Error("Count() failed")
+	}
+
+	database.NewAggregator[int64](db).
+		Table("orders").
+		Where(database.NewQuery().And("@paid").Replace("@paid", "paid_at IS NOT NULL")).
+		Count("*")
+	replaceExp := `SELECT COUNT(*) FROM orders WHERE paid_at IS NOT NULL;`
+	if sql, _ := fake.last(); sql != replaceExp {
+		t.Logf("Expected: %s\nReturns: %s\n", replaceExp, sql)
+		t.Error("Count() replacements failed")
+	}
+}
+
+func TestGroupAggregator(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"status", "count"}, [][]driver.Value{{"paid", int64(3)}, {nil, int64(2)}}
+	})
+
+	counts, err := database.NewGroupAggregator[types.Null[string], int64](db).
+		Table("orders").
+		Where(database.NewQuery().AndGte("total", 10)).
+		GroupBy("status").
+		Count("*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	countExp := `SELECT status, COUNT(*) FROM orders WHERE total >= $1 GROUP BY status;`
+	if sql, _ := fake.last(); sql != countExp {
+		t.Logf("Expected: %s\nReturns: %s\n", countExp, sql)
+		t.Error("Count() failed")
+	}
+	countsExp := map[types.Null[string]]int64{types.NullOf("paid"): 3, {}: 2}
+	if !reflect.DeepEqual(counts, countsExp) {
+		t.Logf("Expected: %v\nReturns: %v\n", countsExp, counts)
+		t.Error("Count() NULL group failed")
+	}
+
+	sums, err := database.NewGroupAggregator[string, int64](db).
+		NumericArgs(false).
+		Table("orders").
+		GroupBy("status").
+		Sum("total")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sumExp := `SELECT status, SUM(total) FROM orders GROUP BY status;`
+	if sql, _ := fake.last(); sql != sumExp || !reflect.DeepEqual(sums, map[string]int64{"paid": 3, "": 2}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", sumExp, sql, sums)
+		t.Error("Sum() failed")
+	}
+}
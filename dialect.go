@@ -0,0 +1,51 @@
+package database
+
+import "strings"
+
+// Dialect sql dialect used by builders to generate dialect specific sql
+type Dialect int
+
+const (
+	// Postgres use numeric ($1, $2) placeholder
+	Postgres Dialect = iota
+	// MySQL use normal (?, ?) placeholder
+	MySQL
+)
+
+// numeric check if dialect use numeric placeholder
+func (dialect Dialect) numeric() bool {
+	return dialect == Postgres
+}
+
+// String get dialect name
+func (dialect Dialect) String() string {
+	switch dialect {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	default:
+		return "unknown"
+	}
+}
+
+// quote quote identifier for dialect, dotted identifiers quoted per part
+func (dialect Dialect) quote(identifier string) string {
+	char := `"`
+	if dialect == MySQL {
+		char = "`"
+	}
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		parts[i] = char + strings.ReplaceAll(part, char, char+char) + char
+	}
+	return strings.Join(parts, ".")
+}
+
+// emptySet get empty subquery used as IN and NOT IN list of empty values
+func (dialect Dialect) emptySet() string {
+	if dialect == MySQL {
+		return "(SELECT NULL FROM DUAL WHERE 1 = 0)"
+	}
+	return "(SELECT NULL WHERE 1 = 0)"
+}
@@ -0,0 +1,124 @@
+package database_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// fakeDB in memory database/sql connector to record executed queries
+//
+// handler resolve result columns and rows of query, nil handler return empty result
+type fakeDB struct {
+	handler  func(query string, args []any) ([]string, [][]driver.Value)
+	affected int64
+	queries  []string
+	args     [][]any
+}
+
+// newFakeDB create sqlx database on top of fake connector
+func newFakeDB(handler func(query string, args []any) ([]string, [][]driver.Value)) (*sqlx.DB, *fakeDB) {
+	fake := &fakeDB{handler: handler, affected: 1}
+	return sqlx.NewDb(sql.OpenDB(fake), "postgres"), fake
+}
+
+// last get last executed query and args
+func (db *fakeDB) last() (string, []any) {
+	if len(db.queries) == 0 {
+		return "", nil
+	}
+	return db.queries[len(db.queries)-1], db.args[len(db.args)-1]
+}
+
+func (db *fakeDB) record(query string, values []driver.Value) []any {
+	args := make([]any, len(values))
+	for i, v := range values {
+		args[i] = v
+	}
+	db.queries = append(db.queries, query)
+	db.args = append(db.args, args)
+	return args
+}
+
+func (db *fakeDB) Connect(context.Context) (driver.Conn, error) { return &fakeConn{db: db}, nil }
+func (db *fakeDB) Driver() driver.Driver                        { return fakeDriver{db: db} }
+
+type fakeDriver struct{ db *fakeDB }
+
+func (d fakeDriver) Open(string) (driver.Conn, error) { return &fakeConn{db: d.db}, nil }
+
+// fakeConn connection of fake database, like postgres driver query on busy connection (with open rows) fails
+type fakeConn struct {
+	db   *fakeDB
+	busy bool
+}
+
+func (conn *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: conn, db: conn.db, query: query}, nil
+}
+func (conn *fakeConn) Close() error              { return nil }
+func (conn *fakeConn) Begin() (driver.Tx, error) { return fakeTx{}, nil }
+
+type fakeTx struct{}
+
+func (fakeTx) Commit() error   { return nil }
+func (fakeTx) Rollback() error { return nil }
+
+type fakeStmt struct {
+	conn  *fakeConn
+	db    *fakeDB
+	query string
+}
+
+func (stmt *fakeStmt) Close() error  { return nil }
+func (stmt *fakeStmt) NumInput() int { return -1 }
+
+func (stmt *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	stmt.db.record(stmt.query, args)
+	return driver.RowsAffected(stmt.db.affected), nil
+}
+
+func (stmt *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if stmt.conn.busy {
+		return nil, errors.New("connection busy")
+	}
+	recorded := stmt.db.record(stmt.query, args)
+	if stmt.db.handler == nil {
+		return &fakeRows{}, nil
+	}
+	columns, rows := stmt.db.handler(stmt.query, recorded)
+	if columns == nil {
+		return nil, errors.New("unexpected query " + stmt.query)
+	}
+	stmt.conn.busy = true
+	return &fakeRows{conn: stmt.conn, columns: columns, rows: rows}, nil
+}
+
+type fakeRows struct {
+	conn    *fakeConn
+	columns []string
+	rows    [][]driver.Value
+	cursor  int
+}
+
+func (rows *fakeRows) Columns() []string { return rows.columns }
+
+func (rows *fakeRows) Close() error {
+	if rows.conn != nil {
+		rows.conn.busy = false
+	}
+	return nil
+}
+
+func (rows *fakeRows) Next(dest []driver.Value) error {
+	if rows.cursor >= len(rows.rows) {
+		return io.EOF
+	}
+	copy(dest, rows.rows[rows.cursor])
+	rows.cursor++
+	return nil
+}
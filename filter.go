@@ -0,0 +1,102 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// FilterType value type of filter field
+type FilterType int
+
+const (
+	// FilterString use value as is
+	FilterString FilterType = iota
+	// FilterInt parse value as int64
+	FilterInt
+	// FilterFloat parse value as float64
+	FilterFloat
+	// FilterBool parse value as bool (1, t, true, 0, f, false)
+	FilterBool
+	// FilterTime parse value as RFC3339 time or 2006-01-02 date
+	FilterTime
+)
+
+// String get filter type name
+func (typ FilterType) String() string {
+	switch typ {
+	case FilterString:
+		return "string"
+	case FilterInt:
+		return "int"
+	case FilterFloat:
+		return "float"
+	case FilterBool:
+		return "bool"
+	case FilterTime:
+		return "time"
+	default:
+		return "unknown"
+	}
+}
+
+// FilterField allowed filter field schema
+//
+// supported operators are eq, ne, gt, gte, lt, lte, in, nin, like, prefix, suffix and null.
+// only eq operator allowed if Operators is empty
+type FilterField struct {
+	// Column sql column or expression, used as is and must not contains user input
+	Column string
+	// Type value type
+	Type FilterType
+	// Operators list of allowed operators
+	Operators []string
+}
+
+// FilterFieldError invalid filter parameter
+type FilterFieldError struct {
+	Key      string
+	Field    string
+	Operator string
+	Message  string
+}
+
+func (err FilterFieldError) Error() string {
+	return fmt.Sprintf("%s: %s", err.Key, err.Message)
+}
+
+// FilterError list of invalid filter parameters
+type FilterError struct {
+	Fields []FilterFieldError
+}
+
+func (err *FilterError) Error() string {
+	messages := make([]string, 0)
+	for _, field := range err.Fields {
+		messages = append(messages, field.Error())
+	}
+	return "invalid filter: " + strings.Join(messages, ", ")
+}
+
+// Filter query builder generator from http query parameters
+//
+// parameters are in `field=value` (eq operator) or `field[operator]=value` format,
+// e.g. `?status=active&age[gte]=18&name[like]=jo`
+type Filter interface {
+	// Ignore skip parameters not related to filter (e.g. sort, page)
+	Ignore(keys ...string) Filter
+	// Parse validate parameters against schema and generate query builder
+	//
+	// empty values skipped, in and nin values are comma separated list
+	// and null value is bool (true for IS NULL, false for IS NOT NULL).
+	// returns *FilterError for unknown fields, disallowed operators or invalid values
+	Parse(values url.Values) (QueryBuilder, error)
+}
+
+// NewFilter generate new filter with allowed fields schema of parameter name to field
+func NewFilter(fields map[string]FilterField) Filter {
+	res := new(filterDriver)
+	res.fields = fields
+	res.ignored = make(map[string]bool)
+	return res
+}
@@ -0,0 +1,198 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type filterDriver struct {
+	fields  map[string]FilterField
+	ignored map[string]bool
+}
+
+func (filter *filterDriver) Ignore(keys ...string) Filter {
+	for _, key := range keys {
+		filter.ignored[key] = true
+	}
+	return filter
+}
+
+func (filter *filterDriver) Parse(values url.Values) (QueryBuilder, error) {
+	query := NewQuery()
+	failed := make([]FilterFieldError, 0)
+
+	// sort keys to generate stable query
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if filter.ignored[key] {
+			continue
+		}
+
+		name, operator, ok := parseFilterKey(key)
+		if ok && filter.ignored[name] {
+			continue
+		}
+		fail := func(message string, args ...any) {
+			failed = append(failed, FilterFieldError{
+				Key:      key,
+				Field:    name,
+				Operator: operator,
+				Message:  fmt.Sprintf(message, args...),
+			})
+		}
+
+		field, exists := filter.fields[name]
+		if !ok || !exists {
+			fail("unknown field")
+			continue
+		} else if !field.allowed(operator) {
+			fail("operator %s not allowed", operator)
+			continue
+		}
+
+		raw := make([]string, 0)
+		for _, value := range values[key] {
+			if value = strings.TrimSpace(value); value != "" {
+				raw = append(raw, value)
+			}
+		}
+		if len(raw) == 0 {
+			continue
+		}
+
+		if operator == "in" || operator == "nin" {
+			args := make([]any, 0)
+			for _, value := range raw {
+				for _, item := range strings.Split(value, ",") {
+					if item = strings.TrimSpace(item); item == "" {
+						continue
+					} else if arg, err := parseFilterValue(item, field.Type); err != nil {
+						fail("invalid %s value %q", field.Type, item)
+					} else {
+						args = append(args, arg)
+					}
+				}
+			}
+			if operator == "in" {
+				query.AndIn(field.Column, args...)
+			} else {
+				query.AndNotIn(field.Column, args...)
+			}
+			continue
+		}
+
+		if len(raw) > 1 {
+			fail("multiple values not allowed")
+			continue
+		}
+
+		var arg any
+		var err error
+		switch operator {
+		case "like", "prefix", "suffix":
+			arg = raw[0]
+		case "null":
+			arg, err = parseFilterValue(raw[0], FilterBool)
+		default:
+			arg, err = parseFilterValue(raw[0], field.Type)
+		}
+		if err != nil {
+			if operator == "null" {
+				fail("invalid bool value %q", raw[0])
+			} else {
+				fail("invalid %s value %q", field.Type, raw[0])
+			}
+			continue
+		}
+
+		switch operator {
+		case "eq":
+			query.AndEq(field.Column, arg)
+		case "ne":
+			query.AndNotEq(field.Column, arg)
+		case "gt":
+			query.AndGt(field.Column, arg)
+		case "gte":
+			query.AndGte(field.Column, arg)
+		case "lt":
+			query.AndLt(field.Column, arg)
+		case "lte":
+			query.AndLte(field.Column, arg)
+		case "like":
+			query.AndContains(field.Column, raw[0])
+		case "prefix":
+			query.AndStartsWith(field.Column, raw[0])
+		case "suffix":
+			query.AndEndsWith(field.Column, raw[0])
+		case "null":
+			query.AndIsNullIf(arg.(bool), field.Column)
+			query.AndNotNullIf(!arg.(bool), field.Column)
+		default:
+			fail("unknown operator %s", operator)
+		}
+	}
+
+	if len(failed) > 0 {
+		return nil, &FilterError{Fields: failed}
+	}
+	return query, nil
+}
+
+// allowed check if operator allowed for field
+func (field FilterField) allowed(operator string) bool {
+	if len(field.Operators) == 0 {
+		return operator == "eq"
+	}
+	for _, op := range field.Operators {
+		if op == operator {
+			return true
+		}
+	}
+	return false
+}
+
+// parseFilterKey split `field[operator]` key to field and operator, operator is eq for `field` key
+func parseFilterKey(key string) (string, string, bool) {
+	open := strings.Index(key, "[")
+	if open < 0 {
+		return key, "eq", !strings.Contains(key, "]")
+	}
+	if open == 0 || !strings.HasSuffix(key, "]") {
+		return key, "", false
+	}
+	name, operator := key[:open], key[open+1:len(key)-1]
+	if operator == "" || strings.ContainsAny(operator, "[]") {
+		return key, "", false
+	}
+	return name, strings.ToLower(operator), true
+}
+
+// parseFilterValue parse string value to filter type
+func parseFilterValue(value string, typ FilterType) (any, error) {
+	switch typ {
+	case FilterString:
+		return value, nil
+	case FilterInt:
+		return strconv.ParseInt(value, 10, 64)
+	case FilterFloat:
+		return strconv.ParseFloat(value, 64)
+	case FilterBool:
+		return strconv.ParseBool(value)
+	case FilterTime:
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		return time.Parse("2006-01-02", value)
+	default:
+		return nil, fmt.Errorf("unknown filter type %d", typ)
+	}
+}
@@ -0,0 +1,44 @@
+package database_test
+
+import (
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"github.com/gomig/database/v2"
+)
+
+func TestFilter(t *testing.T) {
+	filter := database.NewFilter(map[string]database.FilterField{
+		"status": {Column: "status"},
+		"age":    {Column: "age", Type: database.FilterInt, Operators: []string{"gte", "lte"}},
+		"name":   {Column: "u.name", Operators: []string{"eq", "like"}},
+		"id":     {Column: "id", Type: database.FilterInt, Operators: []string{"in"}},
+		"banned": {Column: "banned_at", Operators: []string{"null"}},
+	}).Ignore("sort", "page")
+
+	values, _ := url.ParseQuery("status=active&age[gte]=18&name[like]=j_o&id[in]=1,2&banned[null]=true&sort=-id&page=2")
+	query, err := filter.Parse(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := `age >= $1 AND banned_at IS NULL AND id IN ($2, $3) AND u.name LIKE $4 AND status = $5`
+	if raw := query.Raw(); raw != exp {
+		t.Logf("Expected: %s\nReturns: %s\n", exp, raw)
+		t.Error("Parse() failed")
+	}
+	if args := query.Args(); !reflect.DeepEqual(args, []any{int64(18), int64(1), int64(2), `%j\_o%`, "active"}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("Args() failed")
+	}
+
+	values, _ = url.ParseQuery("age[gte]=abc&age[gt]=1&role=admin&status=a&status=b")
+	var filterErr *database.FilterError
+	if _, err := filter.Parse(values); !errors.As(err, &filterErr) {
+		t.Fatal("Parse() must return FilterError")
+	} else if len(filterErr.Fields) != 4 {
+		t.Logf("Returns: %v\n", err)
+		t.Error("Parse() must return 4 field errors")
+	}
+}
@@ -0,0 +1,48 @@
+package database_test
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/gomig/database/v2"
+)
+
+func TestFinderValue(t *testing.T) {
+	rows := [][]driver.Value{{int64(42), "John"}}
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"max", "name"}, rows
+	})
+
+	value, err := database.NewFinder[repoUser](db).
+		Query(`SELECT MAX(id), name FROM @table WHERE name = ?;`).
+		Replace("@table", "users").
+		Value("John")
+	if err != nil {
+		t.Fatal(err)
+	}
+	valueExp := `SELECT MAX(id), name FROM users WHERE name = $1;`
+	if sql, args := fake.last(); sql != valueExp || !reflect.DeepEqual(args, []any{"John"}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", valueExp, sql, args)
+		t.Error("Value() query failed")
+	}
+	if value != int64(42) {
+		t.Logf("Expected: %v\nReturns: %v\n", 42, value)
+		t.Error("Value() failed")
+	}
+
+	exists, err := database.NewFinder[repoUser](db).Query(`SELECT 1 FROM users;`).Exists()
+	if err != nil || !exists {
+		t.Error("Exists() failed")
+	}
+
+	rows = nil
+	value, err = database.NewFinder[repoUser](db).Query(`SELECT MAX(id) FROM users;`).Value()
+	if err != nil || value != nil {
+		t.Logf("Returns: %v %v\n", value, err)
+		t.Error("Value() without rows failed")
+	}
+	if exists, _ := database.NewFinder[repoUser](db).Query(`SELECT 1 FROM users;`).Exists(); exists {
+		t.Error("Exists() without rows failed")
+	}
+}
@@ -0,0 +1,29 @@
+package database
+
+// InsertSelectBuilder INSERT INTO ... SELECT statement builder
+type InsertSelectBuilder interface {
+	// Dialect set sql dialect, Postgres by default
+	Dialect(dialect Dialect) InsertSelectBuilder
+	// QuoteFields specifies whether to use quoted column names or not, quoted by default
+	QuoteFields(quoted bool) InsertSelectBuilder
+	// Into set target table and columns
+	Into(table string, columns ...string) InsertSelectBuilder
+	// Select set select query of inserted rows
+	Select(query SelectBuilder) InsertSelectBuilder
+	// SQL get generated sql with dialect placeholder
+	SQL() string
+	// Args get list of arguments
+	Args() []any
+	// Debug get generated sql with arguments quoted for dialect
+	//
+	// result is for logging and debugging only and must not be executed
+	Debug() string
+}
+
+// NewInsertSelect generate new insert select statement builder
+func NewInsertSelect() InsertSelectBuilder {
+	res := new(isBuilder)
+	res.dialect = Postgres
+	res.quoted = true
+	return res
+}
@@ -0,0 +1,71 @@
+package database
+
+import "strings"
+
+type isBuilder struct {
+	dialect Dialect
+	quoted  bool
+	table   string
+	columns []string
+	query   SelectBuilder
+}
+
+func (builder *isBuilder) Dialect(dialect Dialect) InsertSelectBuilder {
+	builder.dialect = dialect
+	return builder
+}
+
+func (builder *isBuilder) QuoteFields(quoted bool) InsertSelectBuilder {
+	builder.quoted = quoted
+	return builder
+}
+
+func (builder *isBuilder) Into(table string, columns ...string) InsertSelectBuilder {
+	builder.table = table
+	builder.columns = columns
+	return builder
+}
+
+func (builder *isBuilder) Select(query SelectBuilder) InsertSelectBuilder {
+	builder.query = query
+	return builder
+}
+
+// render generate statement with normal (?) placeholder and arguments list
+func (builder *isBuilder) render() (string, []any) {
+	command := "INSERT INTO " + builder.table
+	if len(builder.columns) > 0 {
+		columns := make([]string, 0, len(builder.columns))
+		for _, column := range builder.columns {
+			if builder.quoted {
+				column = builder.dialect.quote(column)
+			}
+			columns = append(columns, column)
+		}
+		command = command + " (" + strings.Join(columns, ", ") + ")"
+	}
+
+	if builder.query == nil {
+		return command, []any{}
+	}
+	query, args := renderSelect(builder.query)
+	return command + " " + query, args
+}
+
+func (builder *isBuilder) SQL() string {
+	command, _ := builder.render()
+	if builder.dialect.numeric() {
+		command = numericArgs(command, 1)
+	}
+	return command
+}
+
+func (builder *isBuilder) Args() []any {
+	_, args := builder.render()
+	return args
+}
+
+func (builder *isBuilder) Debug() string {
+	command, args := builder.render()
+	return debugComment + interpolate(command, args, builder.dialect)
+}
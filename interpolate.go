@@ -0,0 +1,108 @@
+package database
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// debugComment prefix of interpolated queries
+const debugComment = "/* DEBUG ONLY, NOT FOR EXECUTION */ "
+
+// interpolate replace normal (?) placeholders of query with quoted arguments and escaped ?? with literal ?
+func interpolate(query string, args []any, dialect Dialect) string {
+	return replacePlaceholders(query, true, func(index int) string {
+		if index < len(args) {
+			return quoteValue(args[index], dialect)
+		}
+		return "?"
+	})
+}
+
+// quoteValue get sql literal of value for dialect
+func quoteValue(value any, dialect Dialect) string {
+	if value == nil {
+		return "NULL"
+	}
+
+	// resolve valuer with pointer receiver (e.g. types package)
+	if _, ok := value.(driver.Valuer); !ok {
+		if val := reflect.ValueOf(value); val.Kind() != reflect.Pointer {
+			ptr := reflect.New(val.Type())
+			ptr.Elem().Set(val)
+			if valuer, ok := ptr.Interface().(driver.Valuer); ok {
+				value = valuer
+			}
+		}
+	}
+
+	switch v := value.(type) {
+	case driver.Valuer:
+		if val := reflect.ValueOf(v); val.Kind() == reflect.Pointer && val.IsNil() {
+			return "NULL"
+		}
+		resolved, err := v.Value()
+		if err != nil {
+			return "/* " + strings.ReplaceAll(err.Error(), "*/", "* /") + " */ NULL"
+		}
+		return quoteValue(resolved, dialect)
+	case string:
+		return quoteString(v, dialect)
+	case []byte:
+		if dialect == MySQL {
+			return "X'" + hex.EncodeToString(v) + "'"
+		}
+		return `'\x` + hex.EncodeToString(v) + "'"
+	case time.Time:
+		if dialect == MySQL {
+			return "'" + v.Format("2006-01-02 15:04:05.999999") + "'"
+		}
+		return "'" + v.Format("2006-01-02 15:04:05.999999Z07:00") + "'"
+	case bool:
+		if dialect == MySQL {
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		if v {
+			return "TRUE"
+		}
+		return "FALSE"
+	}
+
+	val := reflect.ValueOf(value)
+	switch val.Kind() {
+	case reflect.Pointer:
+		if val.IsNil() {
+			return "NULL"
+		}
+		return quoteValue(val.Elem().Interface(), dialect)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(val.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(val.Uint(), 10)
+	case reflect.Float32:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 32)
+	case reflect.Float64:
+		return strconv.FormatFloat(val.Float(), 'g', -1, 64)
+	case reflect.String:
+		return quoteString(val.String(), dialect)
+	case reflect.Bool:
+		return quoteValue(val.Bool(), dialect)
+	default:
+		return quoteString(fmt.Sprint(value), dialect)
+	}
+}
+
+// quoteString quote string literal for dialect
+func quoteString(value string, dialect Dialect) string {
+	if dialect == MySQL {
+		value = strings.ReplaceAll(value, `\`, `\\`)
+	}
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
@@ -0,0 +1,55 @@
+package database_test
+
+import (
+	"database/sql/driver"
+	"testing"
+
+	"github.com/gomig/database/v2"
+)
+
+type nestedAddress struct {
+	City string `db:"city"`
+}
+
+type nestedProfile struct {
+	Bio     string        `db:"bio"`
+	Address nestedAddress `q:"addresses" db:"address,nested"`
+}
+
+type nestedUser struct {
+	Id      int64         `db:"id"`
+	Profile nestedProfile `q:"profiles" db:"profile,nested"`
+	Skipped nestedAddress `q:"-" db:"skipped,nested"`
+}
+
+func TestNestedFields(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"id", "profile.bio", "profile.address.city"}, [][]driver.Value{{int64(1), "Hi", "Tehran"}}
+	})
+
+	user, err := database.NewFinder[nestedUser](db).
+		Query(`SELECT @fields FROM users JOIN profiles ON profiles.user_id = users.id;`).
+		Single()
+	if err != nil {
+		t.Fatal(err)
+	}
+	quotedExp := `SELECT "id" ,"profiles"."bio" AS "profile.bio" ,"addresses"."city" AS "profile.address.city" FROM users JOIN profiles ON profiles.user_id = users.id;`
+	if sql, _ := fake.last(); sql != quotedExp {
+		t.Logf("Expected: %s\nReturns: %s\n", quotedExp, sql)
+		t.Error("@fields nested failed")
+	}
+	if user == nil || user.Id != 1 || user.Profile.Bio != "Hi" || user.Profile.Address.City != "Tehran" {
+		t.Logf("Returns: %v\n", user)
+		t.Error("nested scan failed")
+	}
+
+	database.NewFinder[nestedUser](db).
+		QuoteFields(false).
+		Query(`SELECT @fields FROM users;`).
+		Single()
+	rawExp := `SELECT id ,profiles.bio AS "profile.bio" ,addresses.city AS "profile.address.city" FROM users;`
+	if sql, _ := fake.last(); sql != rawExp {
+		t.Logf("Expected: %s\nReturns: %s\n", rawExp, sql)
+		t.Error("@fields nested without quote failed")
+	}
+}
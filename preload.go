@@ -0,0 +1,248 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// tableNamer related struct with custom table name for relations without table option
+type tableNamer interface {
+	TableName() string
+}
+
+// relation parsed `rel` struct tag
+type relation struct {
+	Kind       string
+	Table      string
+	Foreign    string
+	References string
+}
+
+// parseRelation parse `rel:"has_many,table=addresses,foreign=user_id,references=id"` tag
+//
+// table option is optional and resolved from related struct if empty
+func parseRelation(tag string) (relation, error) {
+	kind, options := parseTag(tag)
+	rel := relation{Kind: kind, References: "id"}
+	for _, option := range options {
+		key, value, _ := strings.Cut(option, "=")
+		switch key {
+		case "table":
+			rel.Table = value
+		case "foreign":
+			rel.Foreign = value
+		case "references":
+			rel.References = value
+		default:
+			return rel, fmt.Errorf("unknown relation option %q", key)
+		}
+	}
+
+	if rel.Kind != "has_one" && rel.Kind != "has_many" && rel.Kind != "belongs_to" {
+		return rel, fmt.Errorf("unknown relation type %q", rel.Kind)
+	} else if rel.Foreign == "" {
+		return rel, fmt.Errorf("foreign option required for %s relation", rel.Kind)
+	}
+	return rel, nil
+}
+
+// relationTable get table name of related struct from TableName method or plural snake case of struct name
+func relationTable(typ reflect.Type) string {
+	if namer, ok := reflect.New(typ).Interface().(tableNamer); ok {
+		return namer.TableName()
+	}
+
+	// convert to snake case, e.g. UserProfile -> user_profile and HTTPLog -> http_log
+	runes := []rune(typ.Name())
+	var res strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 &&
+			(!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+			res.WriteByte('_')
+		}
+		res.WriteRune(unicode.ToLower(r))
+	}
+
+	// pluralize, e.g. user -> users, address -> addresses and company -> companies
+	name := res.String()
+	switch {
+	case name == "":
+		return name
+	case strings.HasSuffix(name, "s") || strings.HasSuffix(name, "x") || strings.HasSuffix(name, "z") ||
+		strings.HasSuffix(name, "ch") || strings.HasSuffix(name, "sh"):
+		return name + "es"
+	case strings.HasSuffix(name, "y") && len(name) > 1 && !strings.ContainsAny(name[len(name)-2:len(name)-1], "aeiou"):
+		return name[:len(name)-1] + "ies"
+	default:
+		return name + "s"
+	}
+}
+
+// relationKey get string key of value for matching related records
+func relationKey(val reflect.Value) (any, string, bool) {
+	if val, ok := underlyingValue(val); !ok {
+		return nil, "", false
+	} else {
+		return val.Interface(), fmt.Sprint(val.Interface()), true
+	}
+}
+
+// columnIndex get struct field index by `db` column name
+func columnIndex(typ reflect.Type, column string) (int, bool) {
+	for _, field := range structFields(typ) {
+		if field.Column == column {
+			return field.Index, true
+		}
+	}
+	return 0, false
+}
+
+// preloadChunkSize max number of keys in each preload query
+const preloadChunkSize = 1000
+
+// readRelated read related records of query and group them by key column
+func readRelated(db sqlx.Queryer, query string, keys []any, typ reflect.Type, index int, related map[string][]reflect.Value) error {
+	cursor, err := db.Queryx(query, keys...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	for cursor.Next() {
+		record := reflect.New(typ)
+		if err := cursor.StructScan(record.Interface()); err != nil {
+			return err
+		}
+		if decoder, ok := record.Interface().(IDecoder); ok {
+			if err := decoder.Decode(); err != nil {
+				return err
+			}
+		}
+		if _, key, ok := relationKey(record.Elem().Field(index)); ok {
+			related[key] = append(related[key], record)
+		}
+	}
+	return cursor.Err()
+}
+
+// preload load related records of relation field and set to records
+//
+// records must be addressable slice of structs
+func preload(db sqlx.Queryer, records reflect.Value, name string, numeric, quoted bool) error {
+	parentType := records.Type().Elem()
+	if parentType.Kind() != reflect.Struct {
+		return fmt.Errorf("relation %s not supported for %s", name, parentType)
+	}
+
+	field, ok := parentType.FieldByName(name)
+	if !ok || len(field.Index) != 1 {
+		return fmt.Errorf("relation %s not found", name)
+	}
+
+	tag, ok := field.Tag.Lookup("rel")
+	if !ok {
+		return fmt.Errorf("rel tag not defined for %s", name)
+	}
+
+	rel, err := parseRelation(tag)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+
+	// resolve related struct type
+	relatedType := field.Type
+	if rel.Kind == "has_many" {
+		if relatedType.Kind() != reflect.Slice {
+			return fmt.Errorf("%s: has_many relation field must be slice", name)
+		}
+		relatedType = relatedType.Elem()
+	}
+	isPointer := relatedType.Kind() == reflect.Pointer
+	if isPointer {
+		relatedType = relatedType.Elem()
+	}
+	if relatedType.Kind() != reflect.Struct {
+		return fmt.Errorf("%s: relation field must be struct", name)
+	}
+	if rel.Table == "" {
+		rel.Table = relationTable(relatedType)
+	}
+
+	// resolve key columns
+	parentColumn, relatedColumn := rel.References, rel.Foreign
+	if rel.Kind == "belongs_to" {
+		parentColumn, relatedColumn = rel.Foreign, rel.References
+	}
+	parentIndex, ok := columnIndex(parentType, parentColumn)
+	if !ok {
+		return fmt.Errorf("%s: %s column not found in %s", name, parentColumn, parentType)
+	}
+	relatedIndex, ok := columnIndex(relatedType, relatedColumn)
+	if !ok {
+		return fmt.Errorf("%s: %s column not found in %s", name, relatedColumn, relatedType)
+	}
+
+	// collect distinct keys
+	keys := make([]any, 0)
+	visited := make(map[string]bool)
+	for i := 0; i < records.Len(); i++ {
+		if v, key, ok := relationKey(records.Index(i).Field(parentIndex)); ok && !visited[key] {
+			visited[key] = true
+			keys = append(keys, v)
+		}
+	}
+
+	// read related records in chunks
+	related := make(map[string][]reflect.Value)
+	for start := 0; start < len(keys); start += preloadChunkSize {
+		end := start + preloadChunkSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+
+		placeholders := strings.TrimLeft(strings.Repeat(", ?", end-start), ", ")
+		query := strings.NewReplacer(
+			"@fields", strings.Join(structQueryColumns(reflect.New(relatedType).Interface(), quoted), " ,"),
+			"@table", rel.Table,
+			"@column", quoteField(relatedColumn, quoted),
+			"@keys", placeholders,
+		).Replace("SELECT @fields FROM @table WHERE @column IN (@keys);")
+		query = compileQuery(query, nil, numeric)
+
+		if err := readRelated(db, query, keys[start:end], relatedType, relatedIndex, related); err != nil {
+			return err
+		}
+	}
+
+	// stitch related records to parents
+	for i := 0; i < records.Len(); i++ {
+		target := records.Index(i).Field(field.Index[0])
+		_, key, ok := relationKey(records.Index(i).Field(parentIndex))
+		items := related[key]
+		if !ok {
+			items = nil
+		}
+
+		if rel.Kind == "has_many" {
+			list := reflect.MakeSlice(field.Type, 0, len(items))
+			for _, item := range items {
+				if isPointer {
+					list = reflect.Append(list, item)
+				} else {
+					list = reflect.Append(list, item.Elem())
+				}
+			}
+			target.Set(list)
+		} else if len(items) > 0 {
+			if isPointer {
+				target.Set(items[0])
+			} else {
+				target.Set(items[0].Elem())
+			}
+		}
+	}
+	return nil
+}
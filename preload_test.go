@@ -0,0 +1,186 @@
+package database_test
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gomig/database/v2"
+	"github.com/gomig/database/v2/types"
+)
+
+type relAddress struct {
+	Id     int64  `db:"id"`
+	UserId int64  `db:"user_id"`
+	City   string `db:"city"`
+}
+
+func (relAddress) TableName() string { return "addresses" }
+
+type UserProfile struct {
+	Id     int64  `db:"id"`
+	UserId int64  `db:"user_id"`
+	Bio    string `db:"bio"`
+}
+
+type Company struct {
+	Id   int64  `db:"id"`
+	Name string `db:"name"`
+}
+
+type relUser struct {
+	Id        int64             `db:"id"`
+	CompanyId types.Null[int64] `db:"company_id"`
+	Addresses []relAddress      `rel:"has_many,foreign=user_id"`
+	Profile   *UserProfile      `rel:"has_one,foreign=user_id"`
+	Company   Company           `rel:"belongs_to,foreign=company_id"`
+	Locations []relAddress      `rel:"has_many,table=locations,foreign=user_id"`
+}
+
+func TestPreload(t *testing.T) {
+	users := [][]driver.Value{{int64(1), int64(10)}, {int64(2), nil}, {int64(3), int64(10)}}
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		switch {
+		case strings.Contains(query, "FROM users"):
+			return []string{"id", "company_id"}, users
+		case strings.Contains(query, "FROM addresses"):
+			return []string{"id", "user_id", "city"}, [][]driver.Value{
+				{int64(1), int64(1), "Tehran"}, {int64(2), int64(1), "Shiraz"}, {int64(3), int64(3), "Tabriz"},
+			}
+		case strings.Contains(query, "FROM locations"):
+			return []string{"id", "user_id", "city"}, nil
+		case strings.Contains(query, "FROM user_profiles"):
+			return []string{"id", "user_id", "bio"}, [][]driver.Value{{int64(1), int64(2), "Hi"}}
+		case strings.Contains(query, "FROM companies"):
+			return []string{"id", "name"}, [][]driver.Value{{int64(10), "Acme"}}
+		}
+		return nil, nil
+	})
+
+	result, err := database.NewFinder[relUser](db).
+		Query(`SELECT @fields FROM users;`).
+		Preload("Addresses", "Profile", "Company").
+		Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	queriesExp := []string{
+		`SELECT "id" ,"company_id" FROM users;`,
+		`SELECT "id" ,"user_id" ,"city" FROM addresses WHERE "user_id" IN ($1, $2, $3);`,
+		`SELECT "id" ,"user_id" ,"bio" FROM user_profiles WHERE "user_id" IN ($1, $2, $3);`,
+		`SELECT "id" ,"name" FROM companies WHERE "id" IN ($1);`,
+	}
+	if !reflect.DeepEqual(fake.queries, queriesExp) {
+		t.Logf("Expected: %v\nReturns: %v\n", queriesExp, fake.queries)
+		t.Error("Preload() queries failed")
+	}
+	if !reflect.DeepEqual(fake.args[3], []any{int64(10)}) {
+		t.Logf("Returns: %v\n", fake.args[3])
+		t.Error("Preload() NULL key must be skipped")
+	}
+
+	if len(result) != 3 {
+		t.Fatalf("Preload() 3 records expected, %d given", len(result))
+	}
+	if len(result[0].Addresses) != 2 || len(result[1].Addresses) != 0 || result[1].Addresses == nil ||
+		len(result[2].Addresses) != 1 || result[2].Addresses[0].City != "Tabriz" {
+		t.Logf("Returns: %v\n", result)
+		t.Error("Preload() has_many failed")
+	}
+	if result[0].Profile != nil || result[1].Profile == nil || result[1].Profile.Bio != "Hi" {
+		t.Logf("Returns: %v\n", result)
+		t.Error("Preload() has_one failed")
+	}
+	if result[0].Company.Name != "Acme" || result[1].Company.Name != "" || result[2].Company.Name != "Acme" {
+		t.Logf("Returns: %v\n", result)
+		t.Error("Preload() belongs_to failed")
+	}
+
+	// empty parent set
+	users = nil
+	fake.queries = nil
+	result, err = database.NewFinder[relUser](db).
+		Query(`SELECT @fields FROM users;`).
+		Preload("Addresses", "Profile", "Company").
+		Result()
+	if err != nil || len(result) != 0 || len(fake.queries) != 1 {
+		t.Logf("Returns: %v %v\n", result, fake.queries)
+		t.Error("Preload() empty parent set failed")
+	}
+
+	// explicit table
+	users = [][]driver.Value{{int64(1), nil}}
+	fake.queries = nil
+	if _, err := database.NewFinder[relUser](db).
+		NumericArgs(false).
+		Query(`SELECT @fields FROM users;`).
+		Preload("Locations").
+		Result(); err != nil {
+		t.Fatal(err)
+	}
+	tableExp := `SELECT "id" ,"user_id" ,"city" FROM locations WHERE "user_id" IN (?);`
+	if len(fake.queries) != 2 || fake.queries[1] != tableExp {
+		t.Logf("Expected: %s\nReturns: %v\n", tableExp, fake.queries)
+		t.Error("Preload() table option failed")
+	}
+}
+
+func TestPreloadTransaction(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		if strings.Contains(query, "FROM users") {
+			return []string{"id", "company_id"}, [][]driver.Value{{int64(1), int64(10)}}
+		}
+		return []string{"id", "user_id", "city"}, [][]driver.Value{{int64(1), int64(1), "Tehran"}}
+	})
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+
+	user, err := database.NewFinder[relUser](tx).
+		Query(`SELECT @fields FROM users WHERE id = ?;`).
+		Preload("Addresses").
+		Single(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if user == nil || len(user.Addresses) != 1 || len(fake.queries) != 2 {
+		t.Logf("Returns: %v %v\n", user, fake.queries)
+		t.Error("Single() preload in transaction failed")
+	}
+}
+
+func TestPreloadChunk(t *testing.T) {
+	users := make([][]driver.Value, 0)
+	for i := 1; i <= 1001; i++ {
+		users = append(users, []driver.Value{int64(i), nil})
+	}
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		if strings.Contains(query, "FROM users") {
+			return []string{"id", "company_id"}, users
+		}
+		return []string{"id", "user_id", "city"}, [][]driver.Value{{int64(len(args)), args[0], "Tehran"}}
+	})
+
+	result, err := database.NewFinder[relUser](db).
+		Query(`SELECT @fields FROM users;`).
+		Preload("Addresses").
+		Result()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.queries) != 3 || len(fake.args[1]) != 1000 || len(fake.args[2]) != 1 {
+		t.Logf("Returns: %d queries\n", len(fake.queries))
+		t.Error("Preload() chunk failed")
+	}
+	if len(result[0].Addresses) != 1 || len(result[1000].Addresses) != 1 || len(result[1].Addresses) != 0 {
+		t.Error("Preload() chunk result failed")
+	}
+	if exp := `SELECT "id" ,"user_id" ,"city" FROM addresses WHERE "user_id" IN ($1);`; fake.queries[2] != exp {
+		t.Logf("Expected: %s\nReturns: %s\n", exp, fake.queries[2])
+		t.Error("Preload() last chunk failed")
+	}
+}
@@ -18,8 +18,246 @@ type QueryBuilder interface {
 	OrClosure(query string, args ...any) QueryBuilder
 	// OrClosureIf add new AndClosure condition if first parameter is true
 	OrClosureIf(cond bool, query string, args ...any) QueryBuilder
+	// AndGroup add nested conditions group to query with AND
+	//
+	// group ignored if no condition added to group builder
+	AndGroup(group func(QueryBuilder)) QueryBuilder
+	// AndGroupIf add new AndGroup if first parameter is true
+	AndGroupIf(cond bool, group func(QueryBuilder)) QueryBuilder
+	// OrGroup add nested conditions group to query with OR
+	//
+	// group ignored if no condition added to group builder
+	OrGroup(group func(QueryBuilder)) QueryBuilder
+	// OrGroupIf add new OrGroup if first parameter is true
+	OrGroupIf(cond bool, group func(QueryBuilder)) QueryBuilder
+	// Not wrap all conditions in NOT (...)
+	Not() QueryBuilder
+	// AndNot add conditions of other builder to query with AND in NOT (...)
+	AndNot(other QueryBuilder) QueryBuilder
+	// OrNot add conditions of other builder to query with OR in NOT (...)
+	OrNot(other QueryBuilder) QueryBuilder
+	// Merge add conditions of other builder to query with AND or OR
+	//
+	// conditions wrapped in nested () if other contains multiple conditions
+	Merge(other QueryBuilder, and bool) QueryBuilder
+	// Clone get deep copy of builder
+	//
+	// slice and QueryBuilder arguments copied, SelectBuilder arguments shared with original
+	Clone() QueryBuilder
+	// Reset remove all conditions and replacements
+	Reset() QueryBuilder
+	// IsEmpty check if builder has no condition
+	IsEmpty() bool
+	// Len get number of top level conditions
+	Len() int
+	// AndEq add `column = ?` condition with AND
+	AndEq(column string, value any) QueryBuilder
+	// AndEqIf add new AndEq condition if first parameter is true
+	AndEqIf(cond bool, column string, value any) QueryBuilder
+	// OrEq add `column = ?` condition with OR
+	OrEq(column string, value any) QueryBuilder
+	// OrEqIf add new OrEq condition if first parameter is true
+	OrEqIf(cond bool, column string, value any) QueryBuilder
+	// AndNotEq add `column <> ?` condition with AND
+	AndNotEq(column string, value any) QueryBuilder
+	// AndNotEqIf add new AndNotEq condition if first parameter is true
+	AndNotEqIf(cond bool, column string, value any) QueryBuilder
+	// OrNotEq add `column <> ?` condition with OR
+	OrNotEq(column string, value any) QueryBuilder
+	// OrNotEqIf add new OrNotEq condition if first parameter is true
+	OrNotEqIf(cond bool, column string, value any) QueryBuilder
+	// AndGt add `column > ?` condition with AND
+	AndGt(column string, value any) QueryBuilder
+	// AndGtIf add new AndGt condition if first parameter is true
+	AndGtIf(cond bool, column string, value any) QueryBuilder
+	// OrGt add `column > ?` condition with OR
+	OrGt(column string, value any) QueryBuilder
+	// OrGtIf add new OrGt condition if first parameter is true
+	OrGtIf(cond bool, column string, value any) QueryBuilder
+	// AndGte add `column >= ?` condition with AND
+	AndGte(column string, value any) QueryBuilder
+	// AndGteIf add new AndGte condition if first parameter is true
+	AndGteIf(cond bool, column string, value any) QueryBuilder
+	// OrGte add `column >= ?` condition with OR
+	OrGte(column string, value any) QueryBuilder
+	// OrGteIf add new OrGte condition if first parameter is true
+	OrGteIf(cond bool, column string, value any) QueryBuilder
+	// AndLt add `column < ?` condition with AND
+	AndLt(column string, value any) QueryBuilder
+	// AndLtIf add new AndLt condition if first parameter is true
+	AndLtIf(cond bool, column string, value any) QueryBuilder
+	// OrLt add `column < ?` condition with OR
+	OrLt(column string, value any) QueryBuilder
+	// OrLtIf add new OrLt condition if first parameter is true
+	OrLtIf(cond bool, column string, value any) QueryBuilder
+	// AndLte add `column <= ?` condition with AND
+	AndLte(column string, value any) QueryBuilder
+	// AndLteIf add new AndLte condition if first parameter is true
+	AndLteIf(cond bool, column string, value any) QueryBuilder
+	// OrLte add `column <= ?` condition with OR
+	OrLte(column string, value any) QueryBuilder
+	// OrLteIf add new OrLte condition if first parameter is true
+	OrLteIf(cond bool, column string, value any) QueryBuilder
+	// AndBetween add `column BETWEEN ? AND ?` condition with AND
+	AndBetween(column string, min, max any) QueryBuilder
+	// AndBetweenIf add new AndBetween condition if first parameter is true
+	AndBetweenIf(cond bool, column string, min, max any) QueryBuilder
+	// OrBetween add `column BETWEEN ? AND ?` condition with OR
+	OrBetween(column string, min, max any) QueryBuilder
+	// OrBetweenIf add new OrBetween condition if first parameter is true
+	OrBetweenIf(cond bool, column string, min, max any) QueryBuilder
+	// AndIsNull add `column IS NULL` condition with AND
+	AndIsNull(column string) QueryBuilder
+	// AndIsNullIf add new AndIsNull condition if first parameter is true
+	AndIsNullIf(cond bool, column string) QueryBuilder
+	// OrIsNull add `column IS NULL` condition with OR
+	OrIsNull(column string) QueryBuilder
+	// OrIsNullIf add new OrIsNull condition if first parameter is true
+	OrIsNullIf(cond bool, column string) QueryBuilder
+	// AndNotNull add `column IS NOT NULL` condition with AND
+	AndNotNull(column string) QueryBuilder
+	// AndNotNullIf add new AndNotNull condition if first parameter is true
+	AndNotNullIf(cond bool, column string) QueryBuilder
+	// OrNotNull add `column IS NOT NULL` condition with OR
+	OrNotNull(column string) QueryBuilder
+	// OrNotNullIf add new OrNotNull condition if first parameter is true
+	OrNotNullIf(cond bool, column string) QueryBuilder
+	// AndIn add `column IN (?, ...)` condition with AND
+	AndIn(column string, values ...any) QueryBuilder
+	// AndInIf add new AndIn condition if first parameter is true
+	AndInIf(cond bool, column string, values ...any) QueryBuilder
+	// OrIn add `column IN (?, ...)` condition with OR
+	OrIn(column string, values ...any) QueryBuilder
+	// OrInIf add new OrIn condition if first parameter is true
+	OrInIf(cond bool, column string, values ...any) QueryBuilder
+	// AndNotIn add `column NOT IN (?, ...)` condition with AND
+	AndNotIn(column string, values ...any) QueryBuilder
+	// AndNotInIf add new AndNotIn condition if first parameter is true
+	AndNotInIf(cond bool, column string, values ...any) QueryBuilder
+	// OrNotIn add `column NOT IN (?, ...)` condition with OR
+	OrNotIn(column string, values ...any) QueryBuilder
+	// OrNotInIf add new OrNotIn condition if first parameter is true
+	OrNotInIf(cond bool, column string, values ...any) QueryBuilder
+	// AndLike add `column LIKE ?` condition, pattern used as is with AND
+	AndLike(column string, pattern string) QueryBuilder
+	// AndLikeIf add new AndLike condition if first parameter is true
+	AndLikeIf(cond bool, column string, pattern string) QueryBuilder
+	// OrLike add `column LIKE ?` condition, pattern used as is with OR
+	OrLike(column string, pattern string) QueryBuilder
+	// OrLikeIf add new OrLike condition if first parameter is true
+	OrLikeIf(cond bool, column string, pattern string) QueryBuilder
+	// AndContains add `column LIKE %value%` condition with escaped wildcards with AND
+	AndContains(column string, value string) QueryBuilder
+	// AndContainsIf add new AndContains condition if first parameter is true
+	AndContainsIf(cond bool, column string, value string) QueryBuilder
+	// OrContains add `column LIKE %value%` condition with escaped wildcards with OR
+	OrContains(column string, value string) QueryBuilder
+	// OrContainsIf add new OrContains condition if first parameter is true
+	OrContainsIf(cond bool, column string, value string) QueryBuilder
+	// AndStartsWith add `column LIKE value%` condition with escaped wildcards with AND
+	AndStartsWith(column string, value string) QueryBuilder
+	// AndStartsWithIf add new AndStartsWith condition if first parameter is true
+	AndStartsWithIf(cond bool, column string, value string) QueryBuilder
+	// OrStartsWith add `column LIKE value%` condition with escaped wildcards with OR
+	OrStartsWith(column string, value string) QueryBuilder
+	// OrStartsWithIf add new OrStartsWith condition if first parameter is true
+	OrStartsWithIf(cond bool, column string, value string) QueryBuilder
+	// AndEndsWith add `column LIKE %value` condition with escaped wildcards with AND
+	AndEndsWith(column string, value string) QueryBuilder
+	// AndEndsWithIf add new AndEndsWith condition if first parameter is true
+	AndEndsWithIf(cond bool, column string, value string) QueryBuilder
+	// OrEndsWith add `column LIKE %value` condition with escaped wildcards with OR
+	OrEndsWith(column string, value string) QueryBuilder
+	// OrEndsWithIf add new OrEndsWith condition if first parameter is true
+	OrEndsWithIf(cond bool, column string, value string) QueryBuilder
+	// AndInSub add `column IN (subquery)` condition with AND
+	AndInSub(column string, sub SelectBuilder) QueryBuilder
+	// AndInSubIf add new AndInSub condition if first parameter is true
+	AndInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder
+	// OrInSub add `column IN (subquery)` condition with OR
+	OrInSub(column string, sub SelectBuilder) QueryBuilder
+	// OrInSubIf add new OrInSub condition if first parameter is true
+	OrInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder
+	// AndNotInSub add `column NOT IN (subquery)` condition with AND
+	AndNotInSub(column string, sub SelectBuilder) QueryBuilder
+	// AndNotInSubIf add new AndNotInSub condition if first parameter is true
+	AndNotInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder
+	// OrNotInSub add `column NOT IN (subquery)` condition with OR
+	OrNotInSub(column string, sub SelectBuilder) QueryBuilder
+	// OrNotInSubIf add new OrNotInSub condition if first parameter is true
+	OrNotInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder
+	// AndExists add `EXISTS (subquery)` condition with AND
+	AndExists(sub SelectBuilder) QueryBuilder
+	// AndExistsIf add new AndExists condition if first parameter is true
+	AndExistsIf(cond bool, sub SelectBuilder) QueryBuilder
+	// OrExists add `EXISTS (subquery)` condition with OR
+	OrExists(sub SelectBuilder) QueryBuilder
+	// OrExistsIf add new OrExists condition if first parameter is true
+	OrExistsIf(cond bool, sub SelectBuilder) QueryBuilder
+	// AndNotExists add `NOT EXISTS (subquery)` condition with AND
+	AndNotExists(sub SelectBuilder) QueryBuilder
+	// AndNotExistsIf add new AndNotExists condition if first parameter is true
+	AndNotExistsIf(cond bool, sub SelectBuilder) QueryBuilder
+	// OrNotExists add `NOT EXISTS (subquery)` condition with OR
+	OrNotExists(sub SelectBuilder) QueryBuilder
+	// OrNotExistsIf add new OrNotExists condition if first parameter is true
+	OrNotExistsIf(cond bool, sub SelectBuilder) QueryBuilder
+	// AndJSONEq add `column` value at `path` equality, value compared as text condition with AND
+	AndJSONEq(column string, path string, value any) QueryBuilder
+	// AndJSONEqIf add new AndJSONEq condition if first parameter is true
+	AndJSONEqIf(cond bool, column string, path string, value any) QueryBuilder
+	// OrJSONEq add `column` value at `path` equality, value compared as text condition with OR
+	OrJSONEq(column string, path string, value any) QueryBuilder
+	// OrJSONEqIf add new OrJSONEq condition if first parameter is true
+	OrJSONEqIf(cond bool, column string, path string, value any) QueryBuilder
+	// AndJSONContains add `column` contains json encoded value condition with AND
+	//
+	// condition never match if value can not encoded to json
+	AndJSONContains(column string, value any) QueryBuilder
+	// AndJSONContainsIf add new AndJSONContains condition if first parameter is true
+	AndJSONContainsIf(cond bool, column string, value any) QueryBuilder
+	// OrJSONContains add `column` contains json encoded value condition with OR
+	OrJSONContains(column string, value any) QueryBuilder
+	// OrJSONContainsIf add new OrJSONContains condition if first parameter is true
+	OrJSONContainsIf(cond bool, column string, value any) QueryBuilder
+	// AndJSONHasKey add `column` has top level key condition with AND, key not split as path
+	AndJSONHasKey(column string, key string) QueryBuilder
+	// AndJSONHasKeyIf add new AndJSONHasKey condition if first parameter is true
+	AndJSONHasKeyIf(cond bool, column string, key string) QueryBuilder
+	// OrJSONHasKey add `column` has top level key condition with OR
+	OrJSONHasKey(column string, key string) QueryBuilder
+	// OrJSONHasKeyIf add new OrJSONHasKey condition if first parameter is true
+	OrJSONHasKeyIf(cond bool, column string, key string) QueryBuilder
+	// AndJSONArrayContains add json array of `column` at `path` (empty for root) contains value condition with AND
+	//
+	// condition never match if value can not encoded to json
+	AndJSONArrayContains(column string, path string, value any) QueryBuilder
+	// AndJSONArrayContainsIf add new AndJSONArrayContains condition if first parameter is true
+	AndJSONArrayContainsIf(cond bool, column string, path string, value any) QueryBuilder
+	// OrJSONArrayContains add json array of `column` at `path` (empty for root) contains value condition with OR
+	OrJSONArrayContains(column string, path string, value any) QueryBuilder
+	// OrJSONArrayContainsIf add new OrJSONArrayContains condition if first parameter is true
+	OrJSONArrayContainsIf(cond bool, column string, path string, value any) QueryBuilder
+	// AndSearch add full-text match condition of columns with AND
+	//
+	// input converted to safe tsquery (postgres) or boolean mode query (mysql) and condition ignored if input has no word
+	AndSearch(language string, input string, columns ...string) QueryBuilder
+	// AndSearchIf add new AndSearch condition if first parameter is true
+	AndSearchIf(cond bool, language string, input string, columns ...string) QueryBuilder
+	// OrSearch add full-text match condition of columns with OR
+	//
+	// input converted to safe tsquery (postgres) or boolean mode query (mysql) and condition ignored if input has no word
+	OrSearch(language string, input string, columns ...string) QueryBuilder
+	// OrSearchIf add new OrSearch condition if first parameter is true
+	OrSearchIf(cond bool, language string, input string, columns ...string) QueryBuilder
+	// Dialect set sql dialect of JSON and search helpers and placeholder, Postgres by default
+	//
+	// helpers rendered with dialect of select or update builder if query used as Where or Having
+	Dialect(dialect Dialect) QueryBuilder
 	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
 	NumericArgs(bool) QueryBuilder
+	// ArrayArgs render @in as `= ANY(?)` and @notin as `<> ALL(?)` with single postgres array argument
+	ArrayArgs(array bool) QueryBuilder
 	// NumericStart set numeric argument start for numeric args mode
 	NumericStart(int) QueryBuilder
 	// Replace replace phrase in query string before run
@@ -32,11 +270,18 @@ type QueryBuilder interface {
 	SQL(query string) string
 	// Args get list of arguments
 	Args() []any
+	// Interpolate get generated query with arguments quoted for dialect
+	//
+	// result is for logging and debugging only and must not be executed
+	Interpolate(dialect Dialect) string
+	// Debug get Interpolate result with builder dialect
+	Debug() string
 }
 
 // NewQuery generate new query builder
 func NewQuery() QueryBuilder {
 	res := new(qBuilder)
+	res.dialect = Postgres
 	res.numeric = true
 	res.start = 1
 	return res
@@ -0,0 +1,468 @@
+package database
+
+import "strings"
+
+// escapeLike escape LIKE wildcards (%, _) and escape character (\) of value
+func escapeLike(value string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		`%`, `\%`,
+		`_`, `\_`,
+	).Replace(value)
+}
+
+func (builder *qBuilder) AndEq(column string, value any) QueryBuilder {
+	builder.addItem(column+" = ?", true, false, value)
+	return builder
+}
+
+func (builder *qBuilder) AndEqIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" = ?", true, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrEq(column string, value any) QueryBuilder {
+	builder.addItem(column+" = ?", false, false, value)
+	return builder
+}
+
+func (builder *qBuilder) OrEqIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" = ?", false, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndNotEq(column string, value any) QueryBuilder {
+	builder.addItem(column+" <> ?", true, false, value)
+	return builder
+}
+
+func (builder *qBuilder) AndNotEqIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" <> ?", true, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrNotEq(column string, value any) QueryBuilder {
+	builder.addItem(column+" <> ?", false, false, value)
+	return builder
+}
+
+func (builder *qBuilder) OrNotEqIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" <> ?", false, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndGt(column string, value any) QueryBuilder {
+	builder.addItem(column+" > ?", true, false, value)
+	return builder
+}
+
+func (builder *qBuilder) AndGtIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" > ?", true, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrGt(column string, value any) QueryBuilder {
+	builder.addItem(column+" > ?", false, false, value)
+	return builder
+}
+
+func (builder *qBuilder) OrGtIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" > ?", false, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndGte(column string, value any) QueryBuilder {
+	builder.addItem(column+" >= ?", true, false, value)
+	return builder
+}
+
+func (builder *qBuilder) AndGteIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" >= ?", true, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrGte(column string, value any) QueryBuilder {
+	builder.addItem(column+" >= ?", false, false, value)
+	return builder
+}
+
+func (builder *qBuilder) OrGteIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" >= ?", false, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndLt(column string, value any) QueryBuilder {
+	builder.addItem(column+" < ?", true, false, value)
+	return builder
+}
+
+func (builder *qBuilder) AndLtIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" < ?", true, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrLt(column string, value any) QueryBuilder {
+	builder.addItem(column+" < ?", false, false, value)
+	return builder
+}
+
+func (builder *qBuilder) OrLtIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" < ?", false, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndLte(column string, value any) QueryBuilder {
+	builder.addItem(column+" <= ?", true, false, value)
+	return builder
+}
+
+func (builder *qBuilder) AndLteIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" <= ?", true, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrLte(column string, value any) QueryBuilder {
+	builder.addItem(column+" <= ?", false, false, value)
+	return builder
+}
+
+func (builder *qBuilder) OrLteIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" <= ?", false, false, value)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndBetween(column string, min, max any) QueryBuilder {
+	builder.addItem(column+" BETWEEN ? AND ?", true, false, min, max)
+	return builder
+}
+
+func (builder *qBuilder) AndBetweenIf(cond bool, column string, min, max any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" BETWEEN ? AND ?", true, false, min, max)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrBetween(column string, min, max any) QueryBuilder {
+	builder.addItem(column+" BETWEEN ? AND ?", false, false, min, max)
+	return builder
+}
+
+func (builder *qBuilder) OrBetweenIf(cond bool, column string, min, max any) QueryBuilder {
+	if cond {
+		builder.addItem(column+" BETWEEN ? AND ?", false, false, min, max)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndIsNull(column string) QueryBuilder {
+	builder.addItem(column+" IS NULL", true, false)
+	return builder
+}
+
+func (builder *qBuilder) AndIsNullIf(cond bool, column string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" IS NULL", true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrIsNull(column string) QueryBuilder {
+	builder.addItem(column+" IS NULL", false, false)
+	return builder
+}
+
+func (builder *qBuilder) OrIsNullIf(cond bool, column string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" IS NULL", false, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndNotNull(column string) QueryBuilder {
+	builder.addItem(column+" IS NOT NULL", true, false)
+	return builder
+}
+
+func (builder *qBuilder) AndNotNullIf(cond bool, column string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" IS NOT NULL", true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrNotNull(column string) QueryBuilder {
+	builder.addItem(column+" IS NOT NULL", false, false)
+	return builder
+}
+
+func (builder *qBuilder) OrNotNullIf(cond bool, column string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" IS NOT NULL", false, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndIn(column string, values ...any) QueryBuilder {
+	builder.addIn(column, false, true, values)
+	return builder
+}
+
+func (builder *qBuilder) AndInIf(cond bool, column string, values ...any) QueryBuilder {
+	if cond {
+		builder.addIn(column, false, true, values)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrIn(column string, values ...any) QueryBuilder {
+	builder.addIn(column, false, false, values)
+	return builder
+}
+
+func (builder *qBuilder) OrInIf(cond bool, column string, values ...any) QueryBuilder {
+	if cond {
+		builder.addIn(column, false, false, values)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndNotIn(column string, values ...any) QueryBuilder {
+	builder.addIn(column, true, true, values)
+	return builder
+}
+
+func (builder *qBuilder) AndNotInIf(cond bool, column string, values ...any) QueryBuilder {
+	if cond {
+		builder.addIn(column, true, true, values)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrNotIn(column string, values ...any) QueryBuilder {
+	builder.addIn(column, true, false, values)
+	return builder
+}
+
+func (builder *qBuilder) OrNotInIf(cond bool, column string, values ...any) QueryBuilder {
+	if cond {
+		builder.addIn(column, true, false, values)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndLike(column string, pattern string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", true, false, pattern)
+	return builder
+}
+
+func (builder *qBuilder) AndLikeIf(cond bool, column string, pattern string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", true, false, pattern)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrLike(column string, pattern string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", false, false, pattern)
+	return builder
+}
+
+func (builder *qBuilder) OrLikeIf(cond bool, column string, pattern string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", false, false, pattern)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndContains(column string, value string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", true, false, "%"+escapeLike(value)+"%")
+	return builder
+}
+
+func (builder *qBuilder) AndContainsIf(cond bool, column string, value string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", true, false, "%"+escapeLike(value)+"%")
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrContains(column string, value string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", false, false, "%"+escapeLike(value)+"%")
+	return builder
+}
+
+func (builder *qBuilder) OrContainsIf(cond bool, column string, value string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", false, false, "%"+escapeLike(value)+"%")
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndStartsWith(column string, value string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", true, false, escapeLike(value)+"%")
+	return builder
+}
+
+func (builder *qBuilder) AndStartsWithIf(cond bool, column string, value string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", true, false, escapeLike(value)+"%")
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrStartsWith(column string, value string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", false, false, escapeLike(value)+"%")
+	return builder
+}
+
+func (builder *qBuilder) OrStartsWithIf(cond bool, column string, value string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", false, false, escapeLike(value)+"%")
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndEndsWith(column string, value string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", true, false, "%"+escapeLike(value))
+	return builder
+}
+
+func (builder *qBuilder) AndEndsWithIf(cond bool, column string, value string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", true, false, "%"+escapeLike(value))
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrEndsWith(column string, value string) QueryBuilder {
+	builder.addItem(column+" LIKE ?", false, false, "%"+escapeLike(value))
+	return builder
+}
+
+func (builder *qBuilder) OrEndsWithIf(cond bool, column string, value string) QueryBuilder {
+	if cond {
+		builder.addItem(column+" LIKE ?", false, false, "%"+escapeLike(value))
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndInSub(column string, sub SelectBuilder) QueryBuilder {
+	builder.addItem(column+" IN ?", true, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) AndInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem(column+" IN ?", true, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrInSub(column string, sub SelectBuilder) QueryBuilder {
+	builder.addItem(column+" IN ?", false, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) OrInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem(column+" IN ?", false, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndNotInSub(column string, sub SelectBuilder) QueryBuilder {
+	builder.addItem(column+" NOT IN ?", true, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) AndNotInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem(column+" NOT IN ?", true, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrNotInSub(column string, sub SelectBuilder) QueryBuilder {
+	builder.addItem(column+" NOT IN ?", false, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) OrNotInSubIf(cond bool, column string, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem(column+" NOT IN ?", false, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndExists(sub SelectBuilder) QueryBuilder {
+	builder.addItem("EXISTS ?", true, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) AndExistsIf(cond bool, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem("EXISTS ?", true, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrExists(sub SelectBuilder) QueryBuilder {
+	builder.addItem("EXISTS ?", false, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) OrExistsIf(cond bool, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem("EXISTS ?", false, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndNotExists(sub SelectBuilder) QueryBuilder {
+	builder.addItem("NOT EXISTS ?", true, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) AndNotExistsIf(cond bool, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem("NOT EXISTS ?", true, false, sub)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrNotExists(sub SelectBuilder) QueryBuilder {
+	builder.addItem("NOT EXISTS ?", false, false, sub)
+	return builder
+}
+
+func (builder *qBuilder) OrNotExistsIf(cond bool, sub SelectBuilder) QueryBuilder {
+	if cond {
+		builder.addItem("NOT EXISTS ?", false, false, sub)
+	}
+	return builder
+}
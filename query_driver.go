@@ -2,18 +2,28 @@ package database
 
 import (
 	"math"
+	"reflect"
 	"strings"
+
+	"github.com/lib/pq"
 )
 
+// qItem condition of query builder
+//
+// Build generate dialect dependent condition (e.g. nested builder, JSON and search helpers)
+// with dialect of render time instead of Query and Args
 type qItem struct {
 	Type    string
 	Query   string
 	Args    []any
 	Closure bool
+	Build   func(dialect Dialect) (string, []any)
 }
 
 type qBuilder struct {
+	dialect      Dialect
 	numeric      bool
+	array        bool
 	start        int
 	queries      []qItem
 	replacements []string
@@ -32,6 +42,21 @@ func (builder *qBuilder) addItem(q string, and, closure bool, args ...any) {
 	builder.queries = append(builder.queries, item)
 }
 
+// addBuild add dialect dependent condition rendered on render time
+func (builder *qBuilder) addBuild(build func(dialect Dialect) (string, []any), and, closure bool) {
+	builder.addItem("", and, closure)
+	builder.queries[len(builder.queries)-1].Build = build
+}
+
+// addIn add @in or @notin condition of column
+func (builder *qBuilder) addIn(column string, not, and bool, values []any) {
+	if not {
+		builder.addItem(column+" @notin", and, false, values...)
+	} else {
+		builder.addItem(column+" @in", and, false, values...)
+	}
+}
+
 func (builder *qBuilder) And(query string, args ...any) QueryBuilder {
 	if query != "" {
 		builder.addItem(query, true, false, args...)
@@ -88,11 +113,184 @@ func (builder *qBuilder) OrClosureIf(cond bool, query string, args ...any) Query
 	return builder
 }
 
+// addGroup add nested builder conditions as closure
+func (builder *qBuilder) addGroup(and bool, group func(QueryBuilder)) {
+	if group == nil {
+		return
+	}
+
+	sub := new(qBuilder)
+	sub.dialect = builder.dialect
+	sub.array = builder.array
+	group(sub)
+	if !sub.IsEmpty() {
+		builder.addBuild(sub.renderDialect, and, true)
+	}
+	builder.replacements = append(builder.replacements, sub.replacements...)
+}
+
+func (builder *qBuilder) AndGroup(group func(QueryBuilder)) QueryBuilder {
+	builder.addGroup(true, group)
+	return builder
+}
+
+func (builder *qBuilder) AndGroupIf(cond bool, group func(QueryBuilder)) QueryBuilder {
+	if cond {
+		builder.addGroup(true, group)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrGroup(group func(QueryBuilder)) QueryBuilder {
+	builder.addGroup(false, group)
+	return builder
+}
+
+func (builder *qBuilder) OrGroupIf(cond bool, group func(QueryBuilder)) QueryBuilder {
+	if cond {
+		builder.addGroup(false, group)
+	}
+	return builder
+}
+
+// addBuilder add conditions of other builder as single item
+//
+// conditions of other builder copied on call and rendered with dialect of builder
+func (builder *qBuilder) addBuilder(other QueryBuilder, and, negate bool) {
+	if other == nil || other.IsEmpty() {
+		return
+	}
+
+	var build func(dialect Dialect) (string, []any)
+	if o, ok := other.(*qBuilder); ok {
+		build = o.Clone().(*qBuilder).renderDialect
+		if o != builder {
+			builder.replacements = append(builder.replacements, o.replacements...)
+		}
+	} else {
+		query, args := renderQuery(other, builder.dialect)
+		build = func(Dialect) (string, []any) { return query, args }
+	}
+
+	if negate {
+		builder.addBuild(negateBuild(build), and, false)
+	} else {
+		builder.addBuild(build, and, other.Len() > 1)
+	}
+}
+
+// negateBuild wrap condition of build in NOT (...)
+func negateBuild(build func(dialect Dialect) (string, []any)) func(dialect Dialect) (string, []any) {
+	return func(dialect Dialect) (string, []any) {
+		query, args := build(dialect)
+		return "NOT (" + query + ")", args
+	}
+}
+
+func (builder *qBuilder) Not() QueryBuilder {
+	if !builder.IsEmpty() {
+		negated := &qBuilder{array: builder.array, queries: builder.queries}
+		builder.queries = nil
+		builder.addBuild(negateBuild(negated.renderDialect), true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndNot(other QueryBuilder) QueryBuilder {
+	builder.addBuilder(other, true, true)
+	return builder
+}
+
+func (builder *qBuilder) OrNot(other QueryBuilder) QueryBuilder {
+	builder.addBuilder(other, false, true)
+	return builder
+}
+
+func (builder *qBuilder) Merge(other QueryBuilder, and bool) QueryBuilder {
+	builder.addBuilder(other, and, false)
+	return builder
+}
+
+func (builder *qBuilder) Clone() QueryBuilder {
+	res := *builder
+	res.queries = make([]qItem, len(builder.queries))
+	for i, item := range builder.queries {
+		item.Args = make([]any, len(item.Args))
+		for j, arg := range builder.queries[i].Args {
+			item.Args[j] = cloneArg(arg)
+		}
+		res.queries[i] = item
+	}
+	res.replacements = append([]string{}, builder.replacements...)
+	return &res
+}
+
+// cloneArg copy slice (including types package slices) and QueryBuilder argument, other arguments returned as is
+func cloneArg(arg any) any {
+	if query, ok := arg.(QueryBuilder); ok && query != nil {
+		return query.Clone()
+	}
+
+	val := reflect.ValueOf(arg)
+	if val.Kind() == reflect.Pointer && !val.IsNil() && val.Elem().Kind() == reflect.Struct {
+		if res, ok := cloneSlice(val.Elem()); ok {
+			ptr := reflect.New(res.Type())
+			ptr.Elem().Set(res)
+			return ptr.Interface()
+		}
+	} else if res, ok := cloneSlice(val); ok {
+		return res.Interface()
+	}
+	return arg
+}
+
+// cloneSlice copy slice or struct with single slice field (types package slices)
+func cloneSlice(val reflect.Value) (reflect.Value, bool) {
+	switch {
+	case val.Kind() == reflect.Slice && !val.IsNil():
+		res := reflect.MakeSlice(val.Type(), val.Len(), val.Len())
+		reflect.Copy(res, val)
+		return res, true
+	case val.Kind() == reflect.Struct && val.NumField() == 1 && val.Type().Field(0).IsExported():
+		if items, ok := cloneSlice(val.Field(0)); ok {
+			res := reflect.New(val.Type()).Elem()
+			res.Field(0).Set(items)
+			return res, true
+		}
+	}
+	return val, false
+}
+
+func (builder *qBuilder) Reset() QueryBuilder {
+	builder.queries = nil
+	builder.replacements = nil
+	return builder
+}
+
+func (builder *qBuilder) IsEmpty() bool {
+	return len(builder.queries) == 0
+}
+
+func (builder *qBuilder) Len() int {
+	return len(builder.queries)
+}
+
+func (builder *qBuilder) Dialect(dialect Dialect) QueryBuilder {
+	builder.dialect = dialect
+	builder.numeric = dialect.numeric()
+	return builder
+}
+
 func (builder *qBuilder) NumericArgs(numeric bool) QueryBuilder {
 	builder.numeric = numeric
 	return builder
 }
 
+func (builder *qBuilder) ArrayArgs(array bool) QueryBuilder {
+	builder.array = array
+	return builder
+}
+
 func (builder *qBuilder) NumericStart(start int) QueryBuilder {
 	builder.start = start
 	return builder
@@ -103,20 +301,30 @@ func (builder *qBuilder) Replace(old, new string) QueryBuilder {
 	return builder
 }
 
-func (builder *qBuilder) Raw() string {
+// render generate query with normal (?) placeholder and arguments list with builder dialect
+func (builder *qBuilder) render() (string, []any) {
+	return builder.renderDialect(builder.dialect)
+}
+
+// renderDialect generate query with normal (?) placeholder and arguments list,
+// dialect dependent conditions generated with dialect
+func (builder *qBuilder) renderDialect(dialect Dialect) (string, []any) {
 	command := ""
+	result := make([]any, 0)
 	for _, q := range builder.queries {
-		query := q.Query
-
-		// generate @in
-		if strings.Contains(query, "@in") {
-			placeholders := strings.TrimLeft(
-				strings.Repeat(", ?", len(q.Args)),
-				", ",
-			)
-			query = strings.Replace(query, "@in", "IN ("+placeholders+")", 1)
+		query, args := q.Query, q.Args
+		if q.Build != nil {
+			query, args = q.Build(dialect)
 		}
 
+		// generate @in and @notin
+		if strings.Contains(query, "@in") || strings.Contains(query, "@notin") {
+			query, args = builder.renderIn(query, args, dialect)
+		}
+
+		// generate QueryBuilder and SelectBuilder arguments
+		query, args = renderSubqueries(query, args, dialect)
+
 		// generate subquery
 		if q.Closure {
 			query = "(" + query + ")"
@@ -127,12 +335,105 @@ func (builder *qBuilder) Raw() string {
 		} else {
 			command = command + " " + q.Type + " " + query
 		}
+		result = append(result, args...)
+	}
+	return command, result
+}
+
+// inToken find first @in or @notin token of query, returns -1 if not found
+func inToken(query string) (int, string) {
+	in, notIn := strings.Index(query, "@in"), strings.Index(query, "@notin")
+	if notIn >= 0 && (in < 0 || notIn < in) {
+		return notIn, "@notin"
+	}
+	return in, "@in"
+}
+
+// countPlaceholders count normal (?) placeholders of query
+func countPlaceholders(query string) int {
+	count := 0
+	replacePlaceholders(query, false, func(int) string {
+		count++
+		return "?"
+	})
+	return count
+}
+
+// renderIn generate @in and @notin placeholders with expanded arguments
+//
+// arguments between placeholders before and after token used as list, empty list rendered as empty subquery
+func (builder *qBuilder) renderIn(query string, args []any, dialect Dialect) (string, []any) {
+	for pos, token := inToken(query); pos >= 0; pos, token = inToken(query) {
+		start := countPlaceholders(query[:pos])
+		end := len(args) - countPlaceholders(query[pos+len(token):])
+		if start > len(args) {
+			start = len(args)
+		}
+		if end < start {
+			end = start
+		}
+
+		not := token == "@notin"
+		operand := args[start:end]
+		var expr string
+		var values []any
+		if subqueryArg(operand) {
+			expr, values = "IN ?", operand
+		} else if values = expandArgs(operand); builder.array {
+			var array any = values
+			if len(operand) == 1 {
+				if val, ok := underlyingValue(reflect.ValueOf(operand[0])); ok && val.Kind() == reflect.Slice {
+					array = val.Interface()
+				}
+			}
+			expr, values = "= ANY(?)", []any{pq.Array(array)}
+			if not {
+				expr = "<> ALL(?)"
+			}
+			not = false
+		} else if len(values) == 0 {
+			expr = "IN " + dialect.emptySet()
+		} else {
+			expr = "IN (" + strings.TrimLeft(strings.Repeat(", ?", len(values)), ", ") + ")"
+		}
+		if not {
+			expr = "NOT " + expr
+		}
+
+		query = query[:pos] + expr + query[pos+len(token):]
+		args = append(append(append([]any{}, args[:start]...), values...), args[end:]...)
+	}
+	return query, args
+}
+
+// subqueryArg check if arguments is single QueryBuilder or SelectBuilder
+func subqueryArg(args []any) bool {
+	return len(args) == 1 && isSubquery(args[0])
+}
+
+// expandArgs expand slice arguments (including types package slices) to list of values
+func expandArgs(args []any) []any {
+	res := make([]any, 0)
+	for _, arg := range args {
+		if _, isBytes := arg.([]byte); !isBytes {
+			if val, ok := underlyingValue(reflect.ValueOf(arg)); ok &&
+				(val.Kind() == reflect.Slice || val.Kind() == reflect.Array) {
+				for i := 0; i < val.Len(); i++ {
+					res = append(res, val.Index(i).Interface())
+				}
+				continue
+			}
+		}
+		res = append(res, arg)
 	}
+	return res
+}
 
+func (builder *qBuilder) Raw() string {
+	command, _ := builder.render()
 	if builder.numeric {
 		command = numericArgs(command, int(math.Max(float64(builder.start), 1)))
 	}
-
 	return command
 }
 
@@ -157,9 +458,40 @@ func (builder *qBuilder) SQL(query string) string {
 }
 
 func (builder *qBuilder) Args() []any {
-	args := make([]any, 0)
-	for _, q := range builder.queries {
-		args = append(args, q.Args...)
-	}
+	_, args := builder.render()
 	return args
 }
+
+func (builder *qBuilder) Interpolate(dialect Dialect) string {
+	command, args := builder.render()
+	return debugComment + interpolate(command, args, dialect)
+}
+
+func (builder *qBuilder) Debug() string {
+	return builder.Interpolate(builder.dialect)
+}
+
+// renderQuery get query builder condition with normal (?) placeholder and arguments,
+// dialect dependent conditions generated with dialect of query owner (e.g. select builder)
+func renderQuery(query QueryBuilder, dialect Dialect) (string, []any) {
+	if builder, ok := query.(*qBuilder); ok {
+		return builder.renderDialect(dialect)
+	}
+	return query.Raw(), query.Args()
+}
+
+// queryReplacements get Replace phrases of query builder to apply on sql that use rendered query
+func queryReplacements(query QueryBuilder) []string {
+	if builder, ok := query.(*qBuilder); ok {
+		return builder.replacements
+	}
+	return nil
+}
+
+// queryDialect get dialect of query builder to render query without owner, Postgres for other implementations
+func queryDialect(query QueryBuilder) Dialect {
+	if builder, ok := query.(*qBuilder); ok {
+		return builder.dialect
+	}
+	return Postgres
+}
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// jsonPathParts split dot separated json path, e.g. "address.tags.0"
+func jsonPathParts(path string) []string {
+	res := make([]string, 0)
+	for _, part := range strings.Split(path, ".") {
+		if part = strings.TrimSpace(part); part != "" {
+			res = append(res, part)
+		}
+	}
+	return res
+}
+
+// mysqlJSONKey quote json object key of mysql path, e.g. "address"
+func mysqlJSONKey(key string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(key) + `"`
+}
+
+// mysqlJSONPath convert dot separated json path to mysql path, e.g. $."address"."tags"[0]
+func mysqlJSONPath(path string) string {
+	res := "$"
+	for _, part := range jsonPathParts(path) {
+		if _, err := strconv.ParseUint(part, 10, 64); err == nil {
+			res = res + "[" + part + "]"
+		} else {
+			res = res + "." + mysqlJSONKey(part)
+		}
+	}
+	return res
+}
+
+// jsonValue encode value to json string argument
+func jsonValue(value any) (string, error) {
+	if raw, ok := value.(json.RawMessage); ok {
+		return string(raw), nil
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
+
+// jsonNever condition of value that can not encoded to json, never match
+func jsonNever(Dialect) (string, []any) {
+	return "1 = 0", nil
+}
+
+// jsonEq generate json path equality condition, value compared as text
+func jsonEq(column, path string, value any) func(dialect Dialect) (string, []any) {
+	return func(dialect Dialect) (string, []any) {
+		if dialect == MySQL {
+			return "JSON_UNQUOTE(JSON_EXTRACT(" + column + ", ?)) = ?", []any{mysqlJSONPath(path), value}
+		}
+		return column + " #>> ? = ?", []any{pq.Array(jsonPathParts(path)), value}
+	}
+}
+
+// jsonContains generate json containment condition
+func jsonContains(column string, value any) func(dialect Dialect) (string, []any) {
+	encoded, err := jsonValue(value)
+	if err != nil {
+		return jsonNever
+	}
+	return func(dialect Dialect) (string, []any) {
+		if dialect == MySQL {
+			return "JSON_CONTAINS(" + column + ", ?)", []any{encoded}
+		}
+		return column + " @> ?::jsonb", []any{encoded}
+	}
+}
+
+// jsonHasKey generate json object top level key existence condition, key used as is (not split as path)
+func jsonHasKey(column, key string) func(dialect Dialect) (string, []any) {
+	return func(dialect Dialect) (string, []any) {
+		if dialect == MySQL {
+			return "JSON_CONTAINS_PATH(" + column + ", 'one', ?)", []any{"$." + mysqlJSONKey(key)}
+		}
+		return column + " ?? ?", []any{key}
+	}
+}
+
+// jsonArrayContains generate json array membership condition of array at path
+func jsonArrayContains(column, path string, value any) func(dialect Dialect) (string, []any) {
+	parts := jsonPathParts(path)
+	encoded, err := jsonValue(value)
+	if err != nil {
+		return jsonNever
+	}
+	return func(dialect Dialect) (string, []any) {
+		if dialect == MySQL {
+			if len(parts) == 0 {
+				return "JSON_CONTAINS(" + column + ", ?)", []any{encoded}
+			}
+			return "JSON_CONTAINS(" + column + ", ?, ?)", []any{encoded, mysqlJSONPath(path)}
+		}
+		if len(parts) == 0 {
+			return column + " @> ?::jsonb", []any{"[" + encoded + "]"}
+		}
+		return column + " #> ? @> ?::jsonb", []any{pq.Array(parts), "[" + encoded + "]"}
+	}
+}
+
+func (builder *qBuilder) AndJSONEq(column string, path string, value any) QueryBuilder {
+	builder.addBuild(jsonEq(column, path, value), true, false)
+	return builder
+}
+
+func (builder *qBuilder) AndJSONEqIf(cond bool, column string, path string, value any) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonEq(column, path, value), true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrJSONEq(column string, path string, value any) QueryBuilder {
+	builder.addBuild(jsonEq(column, path, value), false, false)
+	return builder
+}
+
+func (builder *qBuilder) OrJSONEqIf(cond bool, column string, path string, value any) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonEq(column, path, value), false, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndJSONContains(column string, value any) QueryBuilder {
+	builder.addBuild(jsonContains(column, value), true, false)
+	return builder
+}
+
+func (builder *qBuilder) AndJSONContainsIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonContains(column, value), true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrJSONContains(column string, value any) QueryBuilder {
+	builder.addBuild(jsonContains(column, value), false, false)
+	return builder
+}
+
+func (builder *qBuilder) OrJSONContainsIf(cond bool, column string, value any) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonContains(column, value), false, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndJSONHasKey(column string, key string) QueryBuilder {
+	builder.addBuild(jsonHasKey(column, key), true, false)
+	return builder
+}
+
+func (builder *qBuilder) AndJSONHasKeyIf(cond bool, column string, key string) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonHasKey(column, key), true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrJSONHasKey(column string, key string) QueryBuilder {
+	builder.addBuild(jsonHasKey(column, key), false, false)
+	return builder
+}
+
+func (builder *qBuilder) OrJSONHasKeyIf(cond bool, column string, key string) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonHasKey(column, key), false, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) AndJSONArrayContains(column string, path string, value any) QueryBuilder {
+	builder.addBuild(jsonArrayContains(column, path, value), true, false)
+	return builder
+}
+
+func (builder *qBuilder) AndJSONArrayContainsIf(cond bool, column string, path string, value any) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonArrayContains(column, path, value), true, false)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrJSONArrayContains(column string, path string, value any) QueryBuilder {
+	builder.addBuild(jsonArrayContains(column, path, value), false, false)
+	return builder
+}
+
+func (builder *qBuilder) OrJSONArrayContainsIf(cond bool, column string, path string, value any) QueryBuilder {
+	if cond {
+		builder.addBuild(jsonArrayContains(column, path, value), false, false)
+	}
+	return builder
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"strings"
+	"unicode"
+)
+
+// searchTerms split user input to words of letters and digits
+func searchTerms(input string) []string {
+	return strings.FieldsFunc(input, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// ToTSQuery convert user input to safe postgres tsquery with prefix matched words,
+// e.g. "go: sql!" -> "go:* & sql:*"
+func ToTSQuery(input string) string {
+	terms := searchTerms(input)
+	for i := range terms {
+		terms[i] = terms[i] + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
+
+// ToBooleanQuery convert user input to safe mysql boolean mode query with required prefix matched words,
+// e.g. "go: sql!" -> "+go* +sql*"
+func ToBooleanQuery(input string) string {
+	terms := searchTerms(input)
+	for i := range terms {
+		terms[i] = "+" + terms[i] + "*"
+	}
+	return strings.Join(terms, " ")
+}
+
+// searchVector generate postgres tsvector expression of columns
+func searchVector(language string, columns []string) string {
+	document := strings.Join(columns, ", ")
+	if len(columns) > 1 {
+		document = "concat_ws(' ', " + document + ")"
+	}
+	if language == "" {
+		return "to_tsvector(" + document + ")"
+	}
+	return "to_tsvector(" + quoteString(language, Postgres) + ", " + document + ")"
+}
+
+// searchQuery generate postgres tsquery expression
+func searchQuery(language string) string {
+	if language == "" {
+		return "to_tsquery(?)"
+	}
+	return "to_tsquery(" + quoteString(language, Postgres) + ", ?)"
+}
+
+// searchable check if input has word and columns not empty, empty search ignored
+func searchable(input string, columns []string) bool {
+	return len(columns) > 0 && len(searchTerms(input)) > 0
+}
+
+// searchMatch generate full-text match condition
+func searchMatch(dialect Dialect, language, input string, columns []string) (string, []any) {
+	if dialect == MySQL {
+		return "MATCH (" + strings.Join(columns, ", ") + ") AGAINST (? IN BOOLEAN MODE)", []any{ToBooleanQuery(input)}
+	}
+	return searchVector(language, columns) + " @@ " + searchQuery(language), []any{ToTSQuery(input)}
+}
+
+// searchRank generate full-text rank expression
+func searchRank(dialect Dialect, language, input string, columns []string) (string, []any) {
+	if dialect == MySQL {
+		return "MATCH (" + strings.Join(columns, ", ") + ") AGAINST (? IN BOOLEAN MODE)", []any{ToBooleanQuery(input)}
+	}
+	return "ts_rank(" + searchVector(language, columns) + ", " + searchQuery(language) + ")", []any{ToTSQuery(input)}
+}
+
+// addSearch add full-text match condition generated with dialect on render
+func (builder *qBuilder) addSearch(and bool, language, input string, columns []string) {
+	if !searchable(input, columns) {
+		return
+	}
+	columns = append([]string{}, columns...)
+	builder.addBuild(func(dialect Dialect) (string, []any) {
+		return searchMatch(dialect, language, input, columns)
+	}, and, false)
+}
+
+func (builder *qBuilder) AndSearch(language string, input string, columns ...string) QueryBuilder {
+	builder.addSearch(true, language, input, columns)
+	return builder
+}
+
+func (builder *qBuilder) AndSearchIf(cond bool, language string, input string, columns ...string) QueryBuilder {
+	if cond {
+		builder.addSearch(true, language, input, columns)
+	}
+	return builder
+}
+
+func (builder *qBuilder) OrSearch(language string, input string, columns ...string) QueryBuilder {
+	builder.addSearch(false, language, input, columns)
+	return builder
+}
+
+func (builder *qBuilder) OrSearchIf(cond bool, language string, input string, columns ...string) QueryBuilder {
+	if cond {
+		builder.addSearch(false, language, input, columns)
+	}
+	return builder
+}
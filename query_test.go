@@ -1,15 +1,19 @@
 package database_test
 
 import (
+	"database/sql/driver"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/gomig/database/v2"
+	"github.com/gomig/database/v2/types"
 )
 
 func TestQueryBuilder(t *testing.T) {
-	rawExp := `firstname LIKE '%$5%' AND role IN ($6, $7, $8) OR (age > $9 AND age < $10)`
+	rawExp := `firstname LIKE '%?%' OR nickname = $5 AND role IN ($6, $7, $8) OR (age > $9 AND age < $10)`
 	rawQ := database.NewQuery().
-		And("firstname LIKE '%?%'", "John").
+		And("firstname LIKE '%?%' OR nickname = ?", "John").
 		And("role @in", "admin", "support", "user").
 		OrClosure("age > ? AND age < ?", 15, 30).
 		NumericStart(5)
@@ -42,3 +46,352 @@ func TestQueryBuilder(t *testing.T) {
 		t.Error("SQL() failed")
 	}
 }
+
+func TestQueryGroup(t *testing.T) {
+	groupExp := `status = $1 AND (role = $2 OR (age > $3 AND verified = $4))`
+	groupQ := database.NewQuery().
+		And("status = ?", "active").
+		AndGroup(func(q database.QueryBuilder) {
+			q.And("role = ?", "admin").
+				OrGroup(func(q database.QueryBuilder) {
+					q.And("age > ?", 18).And("verified = ?", true)
+				})
+		}).
+		OrGroup(func(q database.QueryBuilder) {
+			q.AndIf(false, "deleted = ?", false)
+		})
+
+	if raw := groupQ.Raw(); raw != groupExp {
+		t.Logf("Expected: %s\nReturns: %s\n", groupExp, raw)
+		t.Error("AndGroup() failed")
+	}
+
+	if len(groupQ.Args()) != 4 {
+		t.Error("Args() failed")
+	}
+
+	replaced := database.NewQuery().AndGroup(func(q database.QueryBuilder) {
+		q.And("deleted_at IS NULL").Replace("@sort", "name")
+	})
+	if sql := replaced.SQL("SELECT * FROM users @where ORDER BY @sort;"); sql != "SELECT * FROM users WHERE (deleted_at IS NULL) ORDER BY name;" {
+		t.Logf("Returns: %s\n", sql)
+		t.Error("AndGroup() replacements failed")
+	}
+}
+
+func TestQueryHelpers(t *testing.T) {
+	helperExp := `status = $1 AND age BETWEEN $2 AND $3 AND deleted_at IS NULL AND name LIKE $4 OR role NOT IN ($5, $6)`
+	helperQ := database.NewQuery().
+		AndEq("status", "active").
+		AndBetween("age", 18, 30).
+		AndIsNull("deleted_at").
+		AndNotNullIf(false, "email").
+		AndContains("name", `50%_off\`).
+		OrNotIn("role", "guest", "banned")
+
+	if raw := helperQ.Raw(); raw != helperExp {
+		t.Logf("Expected: %s\nReturns: %s\n", helperExp, raw)
+		t.Error("Helpers failed")
+	}
+
+	if args := helperQ.Args(); len(args) != 6 || args[3] != `%50\%\_off\\%` {
+		t.Logf("Returns: %v\n", args)
+		t.Error("Contains() escape failed")
+	}
+}
+
+func TestQueryIn(t *testing.T) {
+	inExp := `id IN ($1, $2, $3) AND role NOT IN ($4) AND status IN (SELECT NULL WHERE 1 = 0) OR code NOT IN (SELECT NULL WHERE 1 = 0)`
+	inQ := database.NewQuery().
+		And("id @in", []int{1, 2, 3}).
+		And("role @notin", types.StringSlice{Strings: []string{"guest"}}).
+		AndIn("status", []string{}).
+		OrNotIn("code")
+
+	if raw := inQ.Raw(); raw != inExp {
+		t.Logf("Expected: %s\nReturns: %s\n", inExp, raw)
+		t.Error("@in failed")
+	}
+	if !reflect.DeepEqual(inQ.Args(), []any{1, 2, 3, "guest"}) {
+		t.Logf("Returns: %v\n", inQ.Args())
+		t.Error("@in args failed")
+	}
+
+	emptyExp := "LOWER(name) IN (SELECT NULL FROM DUAL WHERE 1 = 0) AND COALESCE(role, 'guest') NOT IN (SELECT NULL FROM DUAL WHERE 1 = 0) OR (is_admin OR role IN (SELECT NULL FROM DUAL WHERE 1 = 0)) AND (code NOT IN (SELECT NULL FROM DUAL WHERE 1 = 0))"
+	emptyQ := database.NewQuery().
+		Dialect(database.MySQL).
+		AndIn("LOWER(name)", []string{}).
+		AndNotIn("COALESCE(role, 'guest')").
+		OrClosure("is_admin OR role @in", []string{}).
+		AndClosure("code NOT @in", types.StringSlice{Strings: []string{}})
+	if raw := emptyQ.Raw(); raw != emptyExp {
+		t.Logf("Expected: %s\nReturns: %s\n", emptyExp, raw)
+		t.Error("empty @in failed")
+	}
+	if len(emptyQ.Args()) != 0 {
+		t.Logf("Returns: %v\n", emptyQ.Args())
+		t.Error("empty @in args failed")
+	}
+
+	mixedExp := `status = $1 AND id IN ($2, $3) AND (role @> $4 OR name IN ($5)) AND kind NOT IN ($6, $7) AND rank > $8`
+	mixedQ := database.NewQuery().
+		And("status = ? AND id @in", "a", []int{1, 2}).
+		AndClosure("role @> ? OR name @in", []string{"admin"}, "john").
+		And("kind @notin AND rank > ?", "post", "page", 3)
+	if raw := mixedQ.Raw(); raw != mixedExp {
+		t.Logf("Expected: %s\nReturns: %s\n", mixedExp, raw)
+		t.Error("mixed @in failed")
+	}
+	if args := mixedQ.Args(); !reflect.DeepEqual(args, []any{"a", 1, 2, []string{"admin"}, "john", "post", "page", 3}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("mixed @in args failed")
+	}
+
+	anyExp := `id = ANY($1) AND role <> ALL($2)`
+	anyQ := database.NewQuery().
+		ArrayArgs(true).
+		AndIn("id", []int64{1, 2}).
+		AndNotIn("role", "guest", "banned")
+	if raw := anyQ.Raw(); raw != anyExp {
+		t.Logf("Expected: %s\nReturns: %s\n", anyExp, raw)
+		t.Error("ArrayArgs() failed")
+	}
+	if len(anyQ.Args()) != 2 {
+		t.Error("ArrayArgs() args failed")
+	}
+}
+
+// debugPrefix comment prefix of Debug and Interpolate result
+const debugPrefix = "/* DEBUG ONLY, NOT FOR EXECUTION */ "
+
+func TestQueryDebug(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	query := database.NewQuery().
+		And("name = ?", "O'Reilly").
+		And("note = '?'").
+		And("created_at > ?", created).
+		And("deleted_at = ?", nil).
+		And("avatar = ?", []byte{0xde, 0xad}).
+		And("age = ?", types.NullInt{Int: 5, Valid: true}).
+		And("active = ?", true)
+
+	pgExp := `/* DEBUG ONLY, NOT FOR EXECUTION */ name = 'O''Reilly' AND note = '?' AND created_at > '2024-01-02 03:04:05Z' AND deleted_at = NULL AND avatar = '\xdead' AND age = 5 AND active = TRUE`
+	if sql := query.Debug(); sql != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, sql)
+		t.Error("Debug() failed")
+	}
+
+	myExp := `/* DEBUG ONLY, NOT FOR EXECUTION */ name = 'O''Reilly' AND note = '?' AND created_at > '2024-01-02 03:04:05' AND deleted_at = NULL AND avatar = X'dead' AND age = 5 AND active = 1`
+	if sql := query.Interpolate(database.MySQL); sql != myExp {
+		t.Logf("Expected: %s\nReturns: %s\n", myExp, sql)
+		t.Error("Interpolate() failed")
+	}
+	if sql := query.Dialect(database.MySQL).NumericArgs(true).Debug(); sql != myExp {
+		t.Logf("Expected: %s\nReturns: %s\n", myExp, sql)
+		t.Error("Debug() must use builder dialect")
+	}
+
+	// raw and debug agree on quoted placeholder
+	quoted := database.NewQuery().And("name LIKE '%?%' AND code = \"?\" AND id = ?", 5).And("age > ?", 18)
+	if raw := quoted.Raw(); raw != `name LIKE '%?%' AND code = "?" AND id = $1 AND age > $2` {
+		t.Logf("Returns: %s\n", raw)
+		t.Error("Raw() quoted placeholder failed")
+	}
+	if sql := quoted.Debug(); sql != debugPrefix+`name LIKE '%?%' AND code = "?" AND id = 5 AND age > 18` {
+		t.Logf("Returns: %s\n", sql)
+		t.Error("Debug() quoted placeholder failed")
+	}
+}
+
+func TestQueryCompose(t *testing.T) {
+	base := database.NewQuery().And("status = ?", "active").Or("role = ?", "admin")
+	list := base.Clone().And("age > ?", 18)
+	if base.Len() != 2 || list.Len() != 3 {
+		t.Error("Clone() must not share conditions")
+	}
+
+	ids := []int{1, 2}
+	tags := &types.StringSlice{Strings: []string{"a"}}
+	sub := database.NewQuery().And("level > ?", 3)
+	source := database.NewQuery().And("id @in", ids).And("tags = ?", tags).And("role @in", sub)
+	cloned := source.Clone()
+	ids[0], tags.Strings[0] = 9, "b"
+	sub.And("level < ?", 5)
+	cloneExp := []any{1, 2, &types.StringSlice{Strings: []string{"a"}}, 3}
+	if !reflect.DeepEqual(cloned.Args(), cloneExp) {
+		t.Logf("Expected: %v\nReturns: %v\n", cloneExp, cloned.Args())
+		t.Error("Clone() must copy slice and builder arguments")
+	}
+
+	notExp := `NOT (status = $1 OR role = $2)`
+	if raw := base.Clone().Not().Raw(); raw != notExp {
+		t.Logf("Expected: %s\nReturns: %s\n", notExp, raw)
+		t.Error("Not() failed")
+	}
+
+	mergeExp := `age > $1 AND (status = $2 OR role = $3) AND NOT (banned = $4)`
+	merged := database.NewQuery().
+		And("age > ?", 18).
+		Merge(base, true).
+		AndNot(database.NewQuery().And("banned = ?", true)).
+		Merge(database.NewQuery(), false)
+	if raw := merged.Raw(); raw != mergeExp {
+		t.Logf("Expected: %s\nReturns: %s\n", mergeExp, raw)
+		t.Error("Merge() failed")
+	}
+	if !reflect.DeepEqual(merged.Args(), []any{18, "active", "admin", true}) {
+		t.Logf("Returns: %v\n", merged.Args())
+		t.Error("Args() failed")
+	}
+
+	if !merged.Reset().IsEmpty() {
+		t.Error("Reset() failed")
+	}
+}
+
+func TestQuerySubquery(t *testing.T) {
+	orders := database.NewSelect().
+		Columns("user_id").
+		From("orders").
+		Where(database.NewQuery().And("total > ?", 100))
+	banned := database.NewSelect().
+		Columns("1").
+		From("bans b").
+		Where(database.NewQuery().And("b.user_id = u.id").And("b.until > ?", "now"))
+
+	query := database.NewQuery().
+		And("status = ?", "active").
+		AndInSub("u.id", orders).
+		AndNotExists(banned).
+		And("score > ?", database.NewSelect().Columns("AVG(score)").From("users")).
+		And("role @in", database.NewSelect().Columns("name").From("roles").Where(database.NewQuery().And("level > ?", 3))).
+		And("age > ?", 18)
+
+	exp := `status = $1 AND u.id IN (SELECT user_id FROM orders WHERE total > $2) AND NOT EXISTS (SELECT 1 FROM bans b WHERE b.user_id = u.id AND b.until > $3) AND score > (SELECT AVG(score) FROM users) AND role IN (SELECT name FROM roles WHERE level > $4) AND age > $5`
+	if raw := query.Raw(); raw != exp {
+		t.Logf("Expected: %s\nReturns: %s\n", exp, raw)
+		t.Error("Raw() failed")
+	}
+	if !reflect.DeepEqual(query.Args(), []any{"active", 100, "now", 3, 18}) {
+		t.Logf("Returns: %v\n", query.Args())
+		t.Error("Args() failed")
+	}
+
+	// quoted ? not counted as subquery placeholder
+	quoted := database.NewQuery().And("note <> 'a?b' AND id IN ? AND age > ?", orders, 18)
+	quotedExp := `note <> 'a?b' AND id IN (SELECT user_id FROM orders WHERE total > $1) AND age > $2`
+	if raw := quoted.Raw(); raw != quotedExp {
+		t.Logf("Expected: %s\nReturns: %s\n", quotedExp, raw)
+		t.Error("Raw() quoted failed")
+	}
+	if !reflect.DeepEqual(quoted.Args(), []any{100, 18}) {
+		t.Logf("Returns: %v\n", quoted.Args())
+		t.Error("Args() quoted failed")
+	}
+	if sql := quoted.Debug(); sql != debugPrefix+`note <> 'a?b' AND id IN (SELECT user_id FROM orders WHERE total > 100) AND age > 18` {
+		t.Logf("Returns: %s\n", sql)
+		t.Error("Debug() quoted failed")
+	}
+}
+
+func TestQueryJSON(t *testing.T) {
+	build := func(dialect database.Dialect) database.QueryBuilder {
+		return database.NewQuery().
+			Dialect(dialect).
+			AndJSONEq("data", "address.city", "Tehran").
+			AndJSONContains("data", map[string]any{"active": true}).
+			AndJSONHasKey("data", "phone").
+			OrJSONArrayContains("data", "tags.0", "go")
+	}
+
+	pg := build(database.Postgres)
+	pgExp := `data #>> $1 = $2 AND data @> $3::jsonb AND data ? $4 OR data #> $5 @> $6::jsonb`
+	if raw := pg.Raw(); raw != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, raw)
+		t.Error("Raw() failed")
+	}
+	if args := pg.Args(); len(args) != 6 || args[2] != `{"active":true}` || args[3] != "phone" || args[5] != `["go"]` {
+		t.Logf("Returns: %v\n", args)
+		t.Error("Args() failed")
+	}
+
+	my := build(database.MySQL)
+	myExp := `JSON_UNQUOTE(JSON_EXTRACT(data, ?)) = ? AND JSON_CONTAINS(data, ?) AND JSON_CONTAINS_PATH(data, 'one', ?) OR JSON_CONTAINS(data, ?, ?)`
+	if raw := my.Raw(); raw != myExp {
+		t.Logf("Expected: %s\nReturns: %s\n", myExp, raw)
+		t.Error("Raw() failed")
+	}
+	if args := my.Args(); !reflect.DeepEqual(args, []any{`$."address"."city"`, "Tehran", `{"active":true}`, `$."phone"`, `"go"`, `$."tags"[0]`}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("Args() failed")
+	}
+
+	// dialect resolved on render
+	selectExp := "SELECT id FROM users WHERE JSON_CONTAINS_PATH(data, 'one', ?) AND (JSON_CONTAINS(data, ?) OR id IN (SELECT NULL FROM DUAL WHERE 1 = 0))"
+	sel := database.NewSelect().Dialect(database.MySQL).Columns("id").From("users").Where(
+		database.NewQuery().
+			AndJSONHasKey("data", "address.city").
+			AndGroup(func(q database.QueryBuilder) {
+				q.AndJSONContains("data", []string{"go"}).OrIn("id")
+			}),
+	)
+	if sql := sel.SQL(); sql != selectExp {
+		t.Logf("Expected: %s\nReturns: %s\n", selectExp, sql)
+		t.Error("SelectBuilder dialect failed")
+	}
+	if args := sel.Args(); !reflect.DeepEqual(args, []any{`$."address.city"`, `["go"]`}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("JSONHasKey literal key failed")
+	}
+
+	invalidExp := `status = $1 AND 1 = 0`
+	if raw := database.NewQuery().AndEq("status", 1).AndJSONContains("data", func() {}).Raw(); raw != invalidExp {
+		t.Logf("Expected: %s\nReturns: %s\n", invalidExp, raw)
+		t.Error("JSONContains invalid value failed")
+	}
+
+	hasKey := database.NewQuery().AndJSONHasKey("data", "phone").AndEq("id", 1)
+	debugExp := `/* DEBUG ONLY, NOT FOR EXECUTION */ data ? 'phone' AND id = 1`
+	if sql := hasKey.Interpolate(database.Postgres); sql != debugExp {
+		t.Logf("Expected: %s\nReturns: %s\n", debugExp, sql)
+		t.Error("Interpolate() escaped placeholder failed")
+	}
+
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"id", "name"}, nil
+	})
+	findExp := `SELECT id FROM users WHERE data ? $1 AND id = $2;`
+	database.NewFinder[repoUser](db).Query(hasKey.SQL(`SELECT id FROM users WHERE @query;`)).Result(hasKey.Args()...)
+	if sql, args := fake.last(); sql != findExp || !reflect.DeepEqual(args, []any{"phone", int64(1)}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", findExp, sql, args)
+		t.Error("Finder JSONHasKey failed")
+	}
+
+	database.NewFinder[repoUser](db).Select(database.NewSelect().Columns("id").From("users").Where(hasKey)).Result()
+	selectExp = `SELECT id FROM users WHERE data ? $1 AND id = $2`
+	if sql, _ := fake.last(); sql != selectExp {
+		t.Logf("Expected: %s\nReturns: %s\n", selectExp, sql)
+		t.Error("Finder Select JSONHasKey failed")
+	}
+
+	database.NewRepository[repoUser](db, "users").FindAll(hasKey)
+	repoExp := `SELECT "id" ,"name" FROM users WHERE data ? $1 AND id = $2;`
+	if sql, _ := fake.last(); sql != repoExp {
+		t.Logf("Expected: %s\nReturns: %s\n", repoExp, sql)
+		t.Error("Repository JSONHasKey failed")
+	}
+
+	database.NewCMD(db).Command(hasKey.SQL(`DELETE FROM users WHERE @query;`)).Exec(hasKey.Args()...)
+	cmdExp := `DELETE FROM users WHERE data ? $1 AND id = $2;`
+	if sql, _ := fake.last(); sql != cmdExp {
+		t.Logf("Expected: %s\nReturns: %s\n", cmdExp, sql)
+		t.Error("Commander JSONHasKey failed")
+	}
+
+	literalExp := `SELECT '??' AS mark, data ? 'phone' FROM users WHERE id = $1;`
+	database.NewFinder[repoUser](db).Query(`SELECT '??' AS mark, data ?? 'phone' FROM users WHERE id = ?;`).Result(1)
+	if sql, _ := fake.last(); sql != literalExp {
+		t.Logf("Expected: %s\nReturns: %s\n", literalExp, sql)
+		t.Error("Finder quoted ?? failed")
+	}
+}
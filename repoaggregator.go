@@ -0,0 +1,265 @@
+package database
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type Aggregator[V any] interface {
+	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
+	NumericArgs(isNumeric bool) Aggregator[V]
+	// Table table name
+	Table(table string) Aggregator[V]
+	// Where filter records by query
+	Where(query QueryBuilder) Aggregator[V]
+	// Count count non-null values of column, pass * to count all records
+	Count(column string) (int64, error)
+	// Sum get sum of column, returns zero value of V if result is null
+	Sum(column string) (V, error)
+	// Avg get average of column, returns zero value of V if result is null
+	Avg(column string) (V, error)
+	// Min get minimum of column, returns zero value of V if result is null
+	Min(column string) (V, error)
+	// Max get maximum of column, returns zero value of V if result is null
+	Max(column string) (V, error)
+}
+
+// NewAggregator create new aggregator with V result type
+//
+// use nullable types (e.g. types.NullFloat64) to detect null results
+func NewAggregator[V any](db sqlx.Queryer) Aggregator[V] {
+	aggregator := new(aggregatorDriver[V])
+	aggregator.db = db
+	aggregator.numeric = true
+	return aggregator
+}
+
+type aggregatorDriver[V any] struct {
+	db      sqlx.Queryer
+	numeric bool
+	table   string
+	query   QueryBuilder
+}
+
+func (aggregator *aggregatorDriver[V]) NumericArgs(numeric bool) Aggregator[V] {
+	aggregator.numeric = numeric
+	return aggregator
+}
+
+func (aggregator *aggregatorDriver[V]) Table(table string) Aggregator[V] {
+	aggregator.table = table
+	return aggregator
+}
+
+func (aggregator *aggregatorDriver[V]) Where(query QueryBuilder) Aggregator[V] {
+	aggregator.query = query
+	return aggregator
+}
+
+// aggregate run aggregate function and scan nullable result
+func (aggregator *aggregatorDriver[V]) aggregate(function, column string) (V, error) {
+	var result V
+	var value *V
+	sql, args := aggregateSQL(aggregator.query, function, column, aggregator.table, "", aggregator.numeric)
+	if err := aggregator.db.QueryRowx(sql, args...).Scan(&value); err != nil {
+		return result, err
+	} else if value != nil {
+		result = *value
+	}
+	return result, nil
+}
+
+func (aggregator *aggregatorDriver[V]) Count(column string) (int64, error) {
+	var count int64
+	sql, args := aggregateSQL(aggregator.query, "COUNT", column, aggregator.table, "", aggregator.numeric)
+	if err := aggregator.db.QueryRowx(sql, args...).Scan(&count); err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+func (aggregator *aggregatorDriver[V]) Sum(column string) (V, error) {
+	return aggregator.aggregate("SUM", column)
+}
+
+func (aggregator *aggregatorDriver[V]) Avg(column string) (V, error) {
+	return aggregator.aggregate("AVG", column)
+}
+
+func (aggregator *aggregatorDriver[V]) Min(column string) (V, error) {
+	return aggregator.aggregate("MIN", column)
+}
+
+func (aggregator *aggregatorDriver[V]) Max(column string) (V, error) {
+	return aggregator.aggregate("MAX", column)
+}
+
+type GroupAggregator[K comparable, V any] interface {
+	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
+	NumericArgs(isNumeric bool) GroupAggregator[K, V]
+	// Table table name
+	Table(table string) GroupAggregator[K, V]
+	// Where filter records by query
+	Where(query QueryBuilder) GroupAggregator[K, V]
+	// GroupBy group column or expression, group value used as result key
+	GroupBy(column string) GroupAggregator[K, V]
+	// Count count non-null values of column per group, pass * to count all records
+	Count(column string) (map[K]int64, error)
+	// Sum get sum of column per group
+	Sum(column string) (map[K]V, error)
+	// Avg get average of column per group
+	Avg(column string) (map[K]V, error)
+	// Min get minimum of column per group
+	Min(column string) (map[K]V, error)
+	// Max get maximum of column per group
+	Max(column string) (map[K]V, error)
+}
+
+// NewGroupAggregator create new grouped aggregator with K group key and V result type
+//
+// use nullable types (e.g. types.NullString) as key to separate null group
+func NewGroupAggregator[K comparable, V any](db sqlx.Queryer) GroupAggregator[K, V] {
+	aggregator := new(groupAggregatorDriver[K, V])
+	aggregator.db = db
+	aggregator.numeric = true
+	return aggregator
+}
+
+type groupAggregatorDriver[K comparable, V any] struct {
+	db      sqlx.Queryer
+	numeric bool
+	table   string
+	group   string
+	query   QueryBuilder
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) NumericArgs(numeric bool) GroupAggregator[K, V] {
+	aggregator.numeric = numeric
+	return aggregator
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Table(table string) GroupAggregator[K, V] {
+	aggregator.table = table
+	return aggregator
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Where(query QueryBuilder) GroupAggregator[K, V] {
+	aggregator.query = query
+	return aggregator
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) GroupBy(column string) GroupAggregator[K, V] {
+	aggregator.group = column
+	return aggregator
+}
+
+// aggregate run aggregate function per group and scan nullable results
+func (aggregator *groupAggregatorDriver[K, V]) aggregate(function, column string) (map[K]V, error) {
+	sql, args := aggregateSQL(
+		aggregator.query,
+		function,
+		column,
+		aggregator.table,
+		aggregator.group,
+		aggregator.numeric,
+	)
+	cursor, err := aggregator.db.Queryx(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	results := make(map[K]V)
+	for cursor.Next() {
+		var key *K
+		var value *V
+		if err := cursor.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+
+		var k K
+		var v V
+		if key != nil {
+			k = *key
+		}
+		if value != nil {
+			v = *value
+		}
+		results[k] = v
+	}
+	return results, cursor.Err()
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Count(column string) (map[K]int64, error) {
+	sql, args := aggregateSQL(
+		aggregator.query,
+		"COUNT",
+		column,
+		aggregator.table,
+		aggregator.group,
+		aggregator.numeric,
+	)
+	cursor, err := aggregator.db.Queryx(sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+
+	results := make(map[K]int64)
+	for cursor.Next() {
+		var key *K
+		var count int64
+		if err := cursor.Scan(&key, &count); err != nil {
+			return nil, err
+		}
+
+		var k K
+		if key != nil {
+			k = *key
+		}
+		results[k] = count
+	}
+	return results, cursor.Err()
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Sum(column string) (map[K]V, error) {
+	return aggregator.aggregate("SUM", column)
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Avg(column string) (map[K]V, error) {
+	return aggregator.aggregate("AVG", column)
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Min(column string) (map[K]V, error) {
+	return aggregator.aggregate("MIN", column)
+}
+
+func (aggregator *groupAggregatorDriver[K, V]) Max(column string) (map[K]V, error) {
+	return aggregator.aggregate("MAX", column)
+}
+
+// aggregateSQL generate aggregate query and arguments
+//
+// query rendered with normal (?) placeholder, placeholders numbered by numeric parameter
+// and query Replace phrases applied on generated sql
+func aggregateSQL(query QueryBuilder, function, column, table, group string, numeric bool) (string, []any) {
+	fields := function + "(" + column + ")"
+	if group != "" {
+		fields = group + ", " + fields
+	}
+
+	sql := "SELECT " + fields + " FROM " + table
+	args := []any{}
+	var replacements []string
+	if query != nil {
+		replacements = queryReplacements(query)
+		var cond string
+		if cond, args = renderQuery(query, queryDialect(query)); cond != "" {
+			sql += " WHERE " + cond
+		}
+	}
+	if group != "" {
+		sql += " GROUP BY " + group
+	}
+
+	return compileQuery(sql+";", replacements, numeric), args
+}
@@ -2,7 +2,6 @@ package database
 
 import (
 	"database/sql"
-	"strings"
 )
 
 type Commander interface {
@@ -10,6 +9,11 @@ type Commander interface {
 	NumericArgs(isNumeric bool) Commander
 	// Command set sql comman
 	Command(cmd string) Commander
+	// Statement set sql command and arguments from statement builder (e.g. InsertSelectBuilder, UpdateBuilder)
+	//
+	// Exec arguments appended to statement arguments. placeholders of package builders
+	// numbered by builder dialect, call NumericArgs after Statement to override
+	Statement(stmt Statement) Commander
 	// Replace replace phrase in query string before ru
 	Replace(old string, new string) Commander
 	// Exec normalize command and exe
@@ -27,22 +31,12 @@ type cmdDriver struct {
 	db           Executable
 	numeric      bool
 	command      string
+	args         []any
 	replacements []string
 }
 
 func (cmd *cmdDriver) sql() string {
-	if cmd.numeric {
-		return numericArgs(
-			strings.
-				NewReplacer(cmd.replacements...).
-				Replace(cmd.command),
-			1,
-		)
-	} else {
-		return strings.
-			NewReplacer(cmd.replacements...).
-			Replace(cmd.command)
-	}
+	return compileQuery(cmd.command, cmd.replacements, cmd.numeric)
 }
 
 func (cmd *cmdDriver) NumericArgs(numeric bool) Commander {
@@ -55,11 +49,19 @@ func (cmd *cmdDriver) Command(sql string) Commander {
 	return cmd
 }
 
+func (cmd *cmdDriver) Statement(stmt Statement) Commander {
+	cmd.command, cmd.args = renderStatement(stmt)
+	if dialect, ok := statementDialect(stmt); ok {
+		cmd.numeric = dialect.numeric()
+	}
+	return cmd
+}
+
 func (cmd *cmdDriver) Replace(o string, n string) Commander {
 	cmd.replacements = append(cmd.replacements, o, n)
 	return cmd
 }
 
 func (cmd *cmdDriver) Exec(args ...any) (sql.Result, error) {
-	return cmd.db.Exec(cmd.sql(), args...)
+	return cmd.db.Exec(cmd.sql(), mergeArgs(cmd.args, args...)...)
 }
@@ -1,8 +1,6 @@
 package database
 
 import (
-	"strings"
-
 	"github.com/jmoiron/sqlx"
 )
 
@@ -11,13 +9,18 @@ type Counter interface {
 	NumericArgs(isNumeric bool) Counter
 	// Query set sql query
 	Query(query string) Counter
+	// Select set count query and arguments from select builder
+	//
+	// select wrapped as subquery in `SELECT COUNT(*) FROM (...)` without order, limit and offset.
+	// placeholders of package builders numbered by builder dialect, call NumericArgs after Select to override
+	Select(builder SelectBuilder) Counter
 	// Replace replace phrase in query string before run
 	Replace(old string, new string) Counter
 	// Result get count, returns -1 on error
 	Result(args ...any) (int64, error)
 }
 
-func NewCounter(db *sqlx.DB) Counter {
+func NewCounter(db sqlx.Queryer) Counter {
 	counter := new(counterDriver)
 	counter.db = db
 	counter.numeric = true
@@ -25,25 +28,15 @@ func NewCounter(db *sqlx.DB) Counter {
 }
 
 type counterDriver struct {
-	db           *sqlx.DB
+	db           sqlx.Queryer
 	numeric      bool
 	query        string
+	args         []any
 	replacements []string
 }
 
 func (counter *counterDriver) sql() string {
-	if counter.numeric {
-		return numericArgs(
-			strings.
-				NewReplacer(counter.replacements...).
-				Replace(counter.query),
-			1,
-		)
-	} else {
-		return strings.
-			NewReplacer(counter.replacements...).
-			Replace(counter.query)
-	}
+	return compileQuery(counter.query, counter.replacements, counter.numeric)
 }
 
 func (counter *counterDriver) NumericArgs(numeric bool) Counter {
@@ -56,6 +49,14 @@ func (counter *counterDriver) Query(query string) Counter {
 	return counter
 }
 
+func (counter *counterDriver) Select(builder SelectBuilder) Counter {
+	counter.query, counter.args = renderSelectCount(builder)
+	if dialect, ok := statementDialect(builder); ok {
+		counter.numeric = dialect.numeric()
+	}
+	return counter
+}
+
 func (counter *counterDriver) Replace(old, new string) Counter {
 	counter.replacements = append(counter.replacements, old, new)
 	return counter
@@ -63,7 +64,7 @@ func (counter *counterDriver) Replace(old, new string) Counter {
 
 func (counter *counterDriver) Result(args ...any) (int64, error) {
 	var count int64
-	if err := counter.db.Get(&count, counter.sql(), args...); err != nil {
+	if err := sqlx.Get(counter.db, &count, counter.sql(), mergeArgs(counter.args, args...)...); err != nil {
 		return -1, err
 	} else {
 		return count, nil
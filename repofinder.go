@@ -2,6 +2,7 @@ package database
 
 import (
 	"database/sql"
+	"reflect"
 	"strings"
 
 	"github.com/jmoiron/sqlx"
@@ -14,17 +15,30 @@ type Finder[T any] interface {
 	QuoteFields(quoted bool) Finder[T]
 	// Query set sql query
 	Query(query string) Finder[T]
+	// Select set sql query and arguments from select builder
+	//
+	// args passed to Single or Result appended to builder args. placeholders of package builders
+	// numbered by builder dialect, call NumericArgs after Select to override
+	Select(builder SelectBuilder) Finder[T]
 	// Replace replace phrase in query string before run
 	Replace(old string, new string) Finder[T]
 	// Resolve reginster new resolver to run on record after read
 	Resolve(resolver func(*T) error) Finder[T]
+	// Preload load relation fields defined by `rel` struct tag after read
+	//
+	// each relation loaded for whole result with one extra query per 1000 distinct keys
+	Preload(relations ...string) Finder[T]
 	// Single get first result
 	Single(args ...any) (*T, error)
 	// Result get multiple result
 	Result(args ...any) ([]T, error)
+	// Value get first column of first row, returns nil if no rows found
+	Value(args ...any) (any, error)
+	// Exists check if query returns any row
+	Exists(args ...any) (bool, error)
 }
 
-func NewFinder[T any](db *sqlx.DB) Finder[T] {
+func NewFinder[T any](db sqlx.Queryer) Finder[T] {
 	finder := new(finderDriver[T])
 	finder.db = db
 	finder.numeric = true
@@ -33,12 +47,14 @@ func NewFinder[T any](db *sqlx.DB) Finder[T] {
 }
 
 type finderDriver[T any] struct {
-	db           *sqlx.DB
+	db           sqlx.Queryer
 	numeric      bool
 	quoted       bool
 	query        string
+	args         []any
 	replacements []string
 	resolvers    []func(*T) error
+	preloads     []string
 }
 
 func (finder *finderDriver[T]) sql() string {
@@ -51,18 +67,7 @@ func (finder *finderDriver[T]) sql() string {
 		)
 	}
 
-	if finder.numeric {
-		return numericArgs(
-			strings.
-				NewReplacer(finder.replacements...).
-				Replace(finder.query),
-			1,
-		)
-	} else {
-		return strings.
-			NewReplacer(finder.replacements...).
-			Replace(finder.query)
-	}
+	return compileQuery(finder.query, finder.replacements, finder.numeric)
 }
 
 func (finder *finderDriver[T]) NumericArgs(numeric bool) Finder[T] {
@@ -80,6 +85,14 @@ func (finder *finderDriver[T]) Query(query string) Finder[T] {
 	return finder
 }
 
+func (finder *finderDriver[T]) Select(builder SelectBuilder) Finder[T] {
+	finder.query, finder.args = renderSelect(builder)
+	if dialect, ok := statementDialect(builder); ok {
+		finder.numeric = dialect.numeric()
+	}
+	return finder
+}
+
 func (finder *finderDriver[T]) Replace(old, new string) Finder[T] {
 	finder.replacements = append(finder.replacements, old, new)
 	return finder
@@ -90,39 +103,75 @@ func (finder *finderDriver[T]) Resolve(resolver func(*T) error) Finder[T] {
 	return finder
 }
 
+func (finder *finderDriver[T]) Preload(relations ...string) Finder[T] {
+	finder.preloads = append(finder.preloads, relations...)
+	return finder
+}
+
+// resolve load preloads and run resolvers on records
+func (finder *finderDriver[T]) resolve(records []T) error {
+	for _, relation := range finder.preloads {
+		if err := preload(
+			finder.db,
+			reflect.ValueOf(records),
+			relation,
+			finder.numeric,
+			finder.quoted,
+		); err != nil {
+			return err
+		}
+	}
+
+	for i := range records {
+		for _, resolver := range finder.resolvers {
+			if err := resolver(&records[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (finder *finderDriver[T]) Single(args ...any) (*T, error) {
-	if cursor, err := finder.db.Queryx(finder.sql(), args...); err == sql.ErrNoRows {
+	if cursor, err := finder.db.Queryx(finder.sql(), mergeArgs(finder.args, args...)...); err == sql.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
 		return nil, err
 	} else {
 		defer cursor.Close()
-		for cursor.Next() {
-			record := new(T)
+		var record *T
+		if cursor.Next() {
+			record = new(T)
 			if err := cursor.StructScan(record); err != nil {
 				return nil, err
-			} else {
-				if decoder, ok := any(record).(IDecoder); ok {
-					if err := decoder.Decode(); err != nil {
-						return nil, err
-					}
-				}
+			}
+		}
 
-				for _, resolver := range finder.resolvers {
-					if err := resolver(record); err != nil {
-						return nil, err
-					}
-				}
+		// close cursor before resolve, transaction can't run preload queries while cursor is open
+		if err := cursor.Close(); err != nil {
+			return nil, err
+		} else if err := cursor.Err(); err != nil {
+			return nil, err
+		} else if record == nil {
+			return nil, nil
+		}
 
-				return record, nil
+		if decoder, ok := any(record).(IDecoder); ok {
+			if err := decoder.Decode(); err != nil {
+				return nil, err
 			}
 		}
-		return nil, nil
+
+		records := []T{*record}
+		if err := finder.resolve(records); err != nil {
+			return nil, err
+		}
+		return &records[0], nil
 	}
 }
 
 func (finder *finderDriver[T]) Result(args ...any) ([]T, error) {
-	if cursor, err := finder.db.Queryx(finder.sql(), args...); err == sql.ErrNoRows {
+	if cursor, err := finder.db.Queryx(finder.sql(), mergeArgs(finder.args, args...)...); err == sql.ErrNoRows {
 		return []T{}, nil
 	} else if err != nil {
 		return nil, err
@@ -140,15 +189,51 @@ func (finder *finderDriver[T]) Result(args ...any) ([]T, error) {
 					}
 				}
 
-				for _, resolver := range finder.resolvers {
-					if err := resolver(record); err != nil {
-						return nil, err
-					}
-				}
-
 				results = append(results, *record)
 			}
 		}
+
+		if err := cursor.Close(); err != nil {
+			return nil, err
+		} else if err := cursor.Err(); err != nil {
+			return nil, err
+		}
+		if err := finder.resolve(results); err != nil {
+			return nil, err
+		}
 		return results, nil
 	}
 }
+
+func (finder *finderDriver[T]) Value(args ...any) (any, error) {
+	if cursor, err := finder.db.Query(finder.sql(), mergeArgs(finder.args, args...)...); err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		defer cursor.Close()
+		if !cursor.Next() {
+			return nil, cursor.Err()
+		}
+
+		columns, err := cursor.Columns()
+		if err != nil {
+			return nil, err
+		}
+		values := make([]any, len(columns))
+		for i := range values {
+			values[i] = new(any)
+		}
+		if err := cursor.Scan(values...); err != nil {
+			return nil, err
+		}
+		if len(values) == 0 {
+			return nil, nil
+		}
+		return *values[0].(*any), nil
+	}
+}
+
+func (finder *finderDriver[T]) Exists(args ...any) (bool, error) {
+	return rowExists(finder.db, finder.sql(), mergeArgs(finder.args, args...)...)
+}
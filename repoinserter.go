@@ -1,8 +1,11 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"reflect"
 	"strings"
+	"time"
 )
 
 type Inserter[T any] interface {
@@ -12,7 +15,20 @@ type Inserter[T any] interface {
 	QuoteFields(quoted bool) Inserter[T]
 	// Table table name
 	Table(table string) Inserter[T]
+	// Context set context passed to IBeforeInsert and IAfterInsert hooks
+	Context(ctx context.Context) Inserter[T]
+	// Clock set current time resolver for autoCreateTime and autoUpdateTime fields
+	Clock(clock func() time.Time) Inserter[T]
 	// Insert insert and return result
+	//
+	// zero fields tagged with autoCreateTime or autoUpdateTime option filled with current time
+	//
+	// zero fields tagged with pk option (e.g. auto increment id) skipped
+	//
+	// IBeforeInsert and IAfterInsert hooks called if implemented by entity,
+	// entity with hooks must be pointer, otherwise ErrValueEntity returned
+	//
+	// entity validated by `validate` tag rules and IValidator before insert
 	Insert(entity T) (sql.Result, error)
 }
 
@@ -21,6 +37,8 @@ func NewInserter[T any](db Executable) Inserter[T] {
 	inserter.db = db
 	inserter.numeric = true
 	inserter.quoted = true
+	inserter.ctx = context.Background()
+	inserter.clock = time.Now
 	return inserter
 }
 
@@ -29,6 +47,8 @@ type insertDriver[T any] struct {
 	numeric bool
 	quoted  bool
 	table   string
+	ctx     context.Context
+	clock   func() time.Time
 }
 
 func (inserter *insertDriver[T]) NumericArgs(numeric bool) Inserter[T] {
@@ -46,11 +66,64 @@ func (inserter *insertDriver[T]) Table(table string) Inserter[T] {
 	return inserter
 }
 
+func (inserter *insertDriver[T]) Context(ctx context.Context) Inserter[T] {
+	if ctx != nil {
+		inserter.ctx = ctx
+	}
+	return inserter
+}
+
+func (inserter *insertDriver[T]) Clock(clock func() time.Time) Inserter[T] {
+	if clock != nil {
+		inserter.clock = clock
+	}
+	return inserter
+}
+
 func (inserter *insertDriver[T]) Insert(entity T) (sql.Result, error) {
-	fields := structColumns(entity, inserter.quoted)
+	val := indirect(&entity)
+	fields := make([]string, 0)
 	placeholders := make([]string, 0)
-	for range fields {
-		placeholders = append(placeholders, "?")
+	values := make([]any, 0)
+	if val.Kind() == reflect.Struct {
+		_, before := val.Addr().Interface().(IBeforeInsert)
+		_, after := val.Addr().Interface().(IAfterInsert)
+		if (before || after) && !isPointer(entity) {
+			return nil, ErrValueEntity
+		}
+		if hook, ok := val.Addr().Interface().(IBeforeInsert); ok {
+			if err := hook.BeforeInsert(inserter.ctx, inserter.db); err != nil {
+				return nil, err
+			}
+		}
+
+		now := inserter.clock()
+		written := make([]structField, 0)
+		for _, field := range structFields(val.Type()) {
+			if field.Has("nested") {
+				continue
+			}
+			written = append(written, field)
+			if field.Has("pk") && val.Field(field.Index).IsZero() {
+				// auto generated key
+				continue
+			}
+			if field.Has("autoCreateTime") || field.Has("autoUpdateTime") {
+				if !isPointer(entity) {
+					return nil, ErrValueEntity
+				}
+				if val.Field(field.Index).IsZero() {
+					setTime(val.Field(field.Index), now)
+				}
+			}
+			fields = append(fields, quoteField(field.Column, inserter.quoted))
+			placeholders = append(placeholders, "?")
+			values = append(values, val.Field(field.Index).Interface())
+		}
+
+		if err := validateStruct(val, written); err != nil {
+			return nil, err
+		}
 	}
 
 	sql := strings.NewReplacer(
@@ -59,9 +132,19 @@ func (inserter *insertDriver[T]) Insert(entity T) (sql.Result, error) {
 		"@values", strings.Join(placeholders, " ,"),
 	).Replace("INSERT INTO @table (@fields) VALUES(@values);")
 
-	if inserter.numeric {
-		sql = numericArgs(sql, 1)
+	sql = compileQuery(sql, nil, inserter.numeric)
+
+	result, err := inserter.db.Exec(sql, values...)
+	if err != nil {
+		return result, err
 	}
 
-	return inserter.db.Exec(sql, structValues(entity)...)
+	if val.Kind() == reflect.Struct {
+		if hook, ok := val.Addr().Interface().(IAfterInsert); ok {
+			if err := hook.AfterInsert(inserter.ctx, inserter.db); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
 }
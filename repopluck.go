@@ -0,0 +1,94 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type Plucker[V any] interface {
+	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
+	NumericArgs(isNumeric bool) Plucker[V]
+	// Query set sql query, query must select single column
+	Query(query string) Plucker[V]
+	// Replace replace phrase in query string before run
+	Replace(old string, new string) Plucker[V]
+	// Single get first value, returns nil if no rows found
+	Single(args ...any) (*V, error)
+	// Result get column values
+	Result(args ...any) ([]V, error)
+	// Exists check if query returns any row
+	Exists(args ...any) (bool, error)
+}
+
+// Pluck create new single column reader
+func Pluck[V any](db sqlx.Queryer) Plucker[V] {
+	plucker := new(pluckDriver[V])
+	plucker.db = db
+	plucker.numeric = true
+	return plucker
+}
+
+type pluckDriver[V any] struct {
+	db           sqlx.Queryer
+	numeric      bool
+	query        string
+	replacements []string
+}
+
+func (plucker *pluckDriver[V]) sql() string {
+	return compileQuery(plucker.query, plucker.replacements, plucker.numeric)
+}
+
+func (plucker *pluckDriver[V]) NumericArgs(numeric bool) Plucker[V] {
+	plucker.numeric = numeric
+	return plucker
+}
+
+func (plucker *pluckDriver[V]) Query(query string) Plucker[V] {
+	plucker.query = query
+	return plucker
+}
+
+func (plucker *pluckDriver[V]) Replace(old, new string) Plucker[V] {
+	plucker.replacements = append(plucker.replacements, old, new)
+	return plucker
+}
+
+func (plucker *pluckDriver[V]) Single(args ...any) (*V, error) {
+	value := new(V)
+	if err := sqlx.Get(plucker.db, value, plucker.sql(), args...); err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return value, nil
+	}
+}
+
+func (plucker *pluckDriver[V]) Result(args ...any) ([]V, error) {
+	values := make([]V, 0)
+	if err := sqlx.Select(plucker.db, &values, plucker.sql(), args...); err == sql.ErrNoRows {
+		return []V{}, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		return values, nil
+	}
+}
+
+func (plucker *pluckDriver[V]) Exists(args ...any) (bool, error) {
+	return rowExists(plucker.db, plucker.sql(), args...)
+}
+
+// rowExists check if query returns any row
+func rowExists(db sqlx.Queryer, query string, args ...any) (bool, error) {
+	if cursor, err := db.Query(query, args...); err == sql.ErrNoRows {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	} else {
+		defer cursor.Close()
+		return cursor.Next(), cursor.Err()
+	}
+}
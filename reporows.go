@@ -0,0 +1,91 @@
+package database
+
+import (
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type RowsFinder interface {
+	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
+	NumericArgs(isNumeric bool) RowsFinder
+	// Query set sql query
+	Query(query string) RowsFinder
+	// Replace replace phrase in query string before run
+	Replace(old string, new string) RowsFinder
+	// Single get first row as column-value map, returns nil if no rows found
+	Single(args ...any) (map[string]any, error)
+	// Result get rows as column-value map
+	Result(args ...any) ([]map[string]any, error)
+}
+
+// Rows create new reader for ad-hoc shaped rows
+func Rows(db sqlx.Queryer) RowsFinder {
+	rows := new(rowsDriver)
+	rows.db = db
+	rows.numeric = true
+	return rows
+}
+
+type rowsDriver struct {
+	db           sqlx.Queryer
+	numeric      bool
+	query        string
+	replacements []string
+}
+
+func (rows *rowsDriver) sql() string {
+	return compileQuery(rows.query, rows.replacements, rows.numeric)
+}
+
+func (rows *rowsDriver) NumericArgs(numeric bool) RowsFinder {
+	rows.numeric = numeric
+	return rows
+}
+
+func (rows *rowsDriver) Query(query string) RowsFinder {
+	rows.query = query
+	return rows
+}
+
+func (rows *rowsDriver) Replace(old, new string) RowsFinder {
+	rows.replacements = append(rows.replacements, old, new)
+	return rows
+}
+
+func (rows *rowsDriver) Single(args ...any) (map[string]any, error) {
+	if cursor, err := rows.db.Queryx(rows.sql(), args...); err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		defer cursor.Close()
+		for cursor.Next() {
+			record := make(map[string]any)
+			if err := cursor.MapScan(record); err != nil {
+				return nil, err
+			}
+			return record, nil
+		}
+		return nil, cursor.Err()
+	}
+}
+
+func (rows *rowsDriver) Result(args ...any) ([]map[string]any, error) {
+	if cursor, err := rows.db.Queryx(rows.sql(), args...); err == sql.ErrNoRows {
+		return []map[string]any{}, nil
+	} else if err != nil {
+		return nil, err
+	} else {
+		defer cursor.Close()
+		results := make([]map[string]any, 0)
+		for cursor.Next() {
+			record := make(map[string]any)
+			if err := cursor.MapScan(record); err != nil {
+				return nil, err
+			}
+			results = append(results, record)
+		}
+		return results, cursor.Err()
+	}
+}
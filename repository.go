@@ -0,0 +1,432 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+type Repository[T any] interface {
+	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
+	NumericArgs(isNumeric bool) Repository[T]
+	// QuoteFields specifies whether to use quoted field name ("id", "name") or not
+	QuoteFields(quoted bool) Repository[T]
+	// Context set context passed to entity insert, update and delete hooks
+	Context(ctx context.Context) Repository[T]
+	// Clock set current time resolver for autoCreateTime and autoUpdateTime fields
+	Clock(clock func() time.Time) Repository[T]
+	// WithTrashed get repository that include soft deleted records
+	WithTrashed() Repository[T]
+	// OnlyTrashed get repository that include soft deleted records only
+	OnlyTrashed() Repository[T]
+	// FindByID find record by primary key
+	//
+	// for composite keys pass values in struct field order
+	FindByID(id ...any) (*T, error)
+	// FindAll find records matched by query, pass nil to get all records
+	FindAll(query QueryBuilder) ([]T, error)
+	// Create insert entity and return result
+	//
+	// zero valued primary key fields (e.g. auto increment id) not inserted,
+	// changes of auto timestamp fields and hooks applied to entity
+	Create(entity *T) (sql.Result, error)
+	// Update update entity by primary key and return result
+	//
+	// changes of auto timestamp fields and hooks applied to entity
+	Update(entity *T) (sql.Result, error)
+	// Delete permanently delete record by primary key
+	//
+	// IBeforeDelete and IAfterDelete hooks called on loaded record if implemented by entity
+	Delete(id ...any) (sql.Result, error)
+	// SoftDelete set soft delete field of record to current time,
+	// int64 field set to unix timestamp
+	//
+	// IBeforeDelete and IAfterDelete hooks called on loaded record if implemented by entity
+	SoftDelete(id ...any) (sql.Result, error)
+	// Restore clear soft delete field of record
+	Restore(id ...any) (sql.Result, error)
+	// Exists check if record with primary key exists
+	Exists(id ...any) (bool, error)
+	// Count count records matched by query, pass nil to count all records
+	Count(query QueryBuilder) (int64, error)
+}
+
+// NewRepository create new repository for table, db can be database or transaction
+//
+// primary key resolved from pk option of `db` tag, e.g. `db:"id,pk"`
+//
+// soft deleted records excluded automatically if struct contains field
+// with softDelete option, e.g. `db:"deleted_at,softDelete"`,
+// scope applied to repository methods only and Finder and Counter queries must filter soft deleted records manually
+func NewRepository[T any](db Queryable, table string) Repository[T] {
+	repo := new(repositoryDriver[T])
+	repo.db = db
+	repo.table = table
+	repo.numeric = true
+	repo.quoted = true
+	repo.ctx = context.Background()
+	repo.clock = time.Now
+	return repo
+}
+
+type repositoryDriver[T any] struct {
+	db      Queryable
+	table   string
+	numeric bool
+	quoted  bool
+	ctx     context.Context
+	clock   func() time.Time
+	trashed trashedMode
+}
+
+type trashedMode int
+
+const (
+	withoutTrashed trashedMode = iota
+	withTrashed
+	onlyTrashed
+)
+
+// keys get primary key fields
+func (repo *repositoryDriver[T]) keys() ([]structField, error) {
+	var sample T
+	if keys := primaryFields(reflect.TypeOf(sample)); len(keys) == 0 {
+		return nil, errors.New("no primary key defined for " + repo.table)
+	} else {
+		return keys, nil
+	}
+}
+
+// softDeleteField get field with softDelete option
+func (repo *repositoryDriver[T]) softDeleteField() (structField, bool) {
+	var sample T
+	for _, field := range structFields(reflect.TypeOf(sample)) {
+		if field.Has("softDelete") {
+			return field, true
+		}
+	}
+	return structField{}, false
+}
+
+// deletedAt get current time as soft delete field type, e.g. unix timestamp for int64 field
+func (repo *repositoryDriver[T]) deletedAt(field structField) any {
+	var sample T
+	typ := reflect.TypeOf(sample)
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	now := repo.clock()
+	value := reflect.New(typ.Field(field.Index).Type).Elem()
+	if setTime(value, now); value.IsZero() {
+		return now
+	}
+	return value.Interface()
+}
+
+// scope get soft delete condition
+func (repo *repositoryDriver[T]) scope() string {
+	if field, ok := repo.softDeleteField(); !ok {
+		return ""
+	} else if repo.trashed == withTrashed {
+		return ""
+	} else if repo.trashed == onlyTrashed {
+		return quoteField(field.Column, repo.quoted) + " IS NOT NULL"
+	} else {
+		return quoteField(field.Column, repo.quoted) + " IS NULL"
+	}
+}
+
+// scoped replace @where of sql with query condition and soft delete scope
+//
+// query rendered with normal (?) placeholder, placeholders numbered by repository NumericArgs
+// and query Replace phrases applied on sql
+func (repo *repositoryDriver[T]) scoped(query QueryBuilder, sql string) (string, []any) {
+	cond, args := "", []any{}
+	if query != nil {
+		cond, args = renderQuery(query, queryDialect(query))
+		if replacements := queryReplacements(query); len(replacements) > 0 {
+			cond = strings.NewReplacer(replacements...).Replace(cond)
+		}
+	}
+
+	where := ""
+	if scope := repo.scope(); scope != "" && cond != "" {
+		where = " WHERE (" + cond + ") AND " + scope
+	} else if scope != "" {
+		where = " WHERE " + scope
+	} else if cond != "" {
+		where = " WHERE " + cond
+	}
+	return strings.Replace(sql, " @where", where, 1), args
+}
+
+// scopedKeyCondition generate primary key condition with soft delete scope
+func (repo *repositoryDriver[T]) scopedKeyCondition(ids ...any) (string, error) {
+	if cond, err := repo.keyCondition(ids...); err != nil {
+		return "", err
+	} else if scope := repo.scope(); scope != "" {
+		return cond + " AND " + scope, nil
+	} else {
+		return cond, nil
+	}
+}
+
+// keyCondition generate primary key condition
+func (repo *repositoryDriver[T]) keyCondition(ids ...any) (string, error) {
+	keys, err := repo.keys()
+	if err != nil {
+		return "", err
+	}
+
+	if len(keys) != len(ids) {
+		return "", fmt.Errorf("%d primary key value expected, %d given", len(keys), len(ids))
+	}
+
+	conditions := make([]string, 0)
+	for _, key := range keys {
+		conditions = append(conditions, quoteField(key.Column, repo.quoted)+" = ?")
+	}
+	return strings.Join(conditions, " AND "), nil
+}
+
+// hooked load record for delete hooks
+//
+// returns nil if entity not implements delete hooks or record not found
+func (repo *repositoryDriver[T]) hooked(mode trashedMode, id ...any) (*T, error) {
+	var sample T
+	_, before := any(&sample).(IBeforeDelete)
+	_, after := any(&sample).(IAfterDelete)
+	if !before && !after {
+		return nil, nil
+	}
+
+	clone := *repo
+	clone.trashed = mode
+	return clone.FindByID(id...)
+}
+
+// deleteWithHooks run delete command between entity delete hooks
+func (repo *repositoryDriver[T]) deleteWithHooks(entity *T, command func() (sql.Result, error)) (sql.Result, error) {
+	if entity != nil {
+		if hook, ok := any(entity).(IBeforeDelete); ok {
+			if err := hook.BeforeDelete(repo.ctx, repo.db); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	result, err := command()
+	if err != nil || entity == nil {
+		return result, err
+	}
+
+	if hook, ok := any(entity).(IAfterDelete); ok {
+		if err := hook.AfterDelete(repo.ctx, repo.db); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+func (repo *repositoryDriver[T]) NumericArgs(numeric bool) Repository[T] {
+	repo.numeric = numeric
+	return repo
+}
+
+func (repo *repositoryDriver[T]) QuoteFields(quoted bool) Repository[T] {
+	repo.quoted = quoted
+	return repo
+}
+
+func (repo *repositoryDriver[T]) Context(ctx context.Context) Repository[T] {
+	if ctx != nil {
+		repo.ctx = ctx
+	}
+	return repo
+}
+
+func (repo *repositoryDriver[T]) Clock(clock func() time.Time) Repository[T] {
+	if clock != nil {
+		repo.clock = clock
+	}
+	return repo
+}
+
+func (repo *repositoryDriver[T]) WithTrashed() Repository[T] {
+	clone := *repo
+	clone.trashed = withTrashed
+	return &clone
+}
+
+func (repo *repositoryDriver[T]) OnlyTrashed() Repository[T] {
+	clone := *repo
+	clone.trashed = onlyTrashed
+	return &clone
+}
+
+func (repo *repositoryDriver[T]) FindByID(id ...any) (*T, error) {
+	cond, err := repo.scopedKeyCondition(id...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFinder[T](repo.db).
+		NumericArgs(repo.numeric).
+		QuoteFields(repo.quoted).
+		Query(`SELECT @fields FROM @table WHERE @cond;`).
+		Replace("@table", repo.table).
+		Replace("@cond", cond).
+		Single(id...)
+}
+
+func (repo *repositoryDriver[T]) FindAll(query QueryBuilder) ([]T, error) {
+	sql, args := repo.scoped(query, `SELECT @fields FROM @table @where;`)
+	return NewFinder[T](repo.db).
+		NumericArgs(repo.numeric).
+		QuoteFields(repo.quoted).
+		Query(sql).
+		Replace("@table", repo.table).
+		Result(args...)
+}
+
+func (repo *repositoryDriver[T]) Create(entity *T) (sql.Result, error) {
+	if entity == nil {
+		return nil, errors.New("invalid entity")
+	}
+
+	return NewInserter[*T](repo.db).
+		NumericArgs(repo.numeric).
+		QuoteFields(repo.quoted).
+		Context(repo.ctx).
+		Clock(repo.clock).
+		Table(repo.table).
+		Insert(entity)
+}
+
+func (repo *repositoryDriver[T]) Update(entity *T) (sql.Result, error) {
+	keys, err := repo.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	val := indirect(entity)
+	if val.Kind() != reflect.Struct {
+		return nil, errors.New("invalid entity")
+	}
+
+	ids := make([]any, 0)
+	for _, key := range keys {
+		ids = append(ids, val.Field(key.Index).Interface())
+	}
+
+	cond, err := repo.scopedKeyCondition(ids...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewUpdater[*T](repo.db).
+		NumericArgs(repo.numeric).
+		QuoteFields(repo.quoted).
+		Context(repo.ctx).
+		Clock(repo.clock).
+		Table(repo.table).
+		Where(cond, ids...).
+		Update(entity)
+}
+
+func (repo *repositoryDriver[T]) Delete(id ...any) (sql.Result, error) {
+	cond, err := repo.keyCondition(id...)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := repo.hooked(withTrashed, id...)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.deleteWithHooks(entity, func() (sql.Result, error) {
+		return NewCMD(repo.db).
+			NumericArgs(repo.numeric).
+			Command(`DELETE FROM @table WHERE @cond;`).
+			Replace("@table", repo.table).
+			Replace("@cond", cond).
+			Exec(id...)
+	})
+}
+
+func (repo *repositoryDriver[T]) SoftDelete(id ...any) (sql.Result, error) {
+	field, ok := repo.softDeleteField()
+	if !ok {
+		return nil, errors.New("no soft delete field defined for " + repo.table)
+	}
+
+	cond, err := repo.keyCondition(id...)
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := repo.hooked(withoutTrashed, id...)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.deleteWithHooks(entity, func() (sql.Result, error) {
+		return NewCMD(repo.db).
+			NumericArgs(repo.numeric).
+			Command(`UPDATE @table SET @field = ? WHERE @cond AND @field IS NULL;`).
+			Replace("@table", repo.table).
+			Replace("@field", quoteField(field.Column, repo.quoted)).
+			Replace("@cond", cond).
+			Exec(append([]any{repo.deletedAt(field)}, id...)...)
+	})
+}
+
+func (repo *repositoryDriver[T]) Restore(id ...any) (sql.Result, error) {
+	field, ok := repo.softDeleteField()
+	if !ok {
+		return nil, errors.New("no soft delete field defined for " + repo.table)
+	}
+
+	cond, err := repo.keyCondition(id...)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCMD(repo.db).
+		NumericArgs(repo.numeric).
+		Command(`UPDATE @table SET @field = NULL WHERE @cond AND @field IS NOT NULL;`).
+		Replace("@table", repo.table).
+		Replace("@field", quoteField(field.Column, repo.quoted)).
+		Replace("@cond", cond).
+		Exec(id...)
+}
+
+func (repo *repositoryDriver[T]) Exists(id ...any) (bool, error) {
+	cond, err := repo.scopedKeyCondition(id...)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := NewCounter(repo.db).
+		NumericArgs(repo.numeric).
+		Query(`SELECT COUNT(*) FROM @table WHERE @cond;`).
+		Replace("@table", repo.table).
+		Replace("@cond", cond).
+		Result(id...)
+	return count > 0, err
+}
+
+func (repo *repositoryDriver[T]) Count(query QueryBuilder) (int64, error) {
+	sql, args := repo.scoped(query, `SELECT COUNT(*) FROM @table @where;`)
+	return NewCounter(repo.db).
+		NumericArgs(repo.numeric).
+		Query(sql).
+		Replace("@table", repo.table).
+		Result(args...)
+}
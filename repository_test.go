@@ -0,0 +1,444 @@
+package database_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gomig/database/v2"
+	"github.com/gomig/database/v2/types"
+)
+
+type repoUser struct {
+	Id   int64  `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func TestRepositoryQuery(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"id", "name"}, [][]driver.Value{{int64(1), "John"}}
+	})
+	repo := database.NewRepository[repoUser](db, "users")
+
+	query := database.NewQuery().
+		AndIn("id", 1, 2).
+		AndClosure("name = ? OR name = ?", "John", "Jack")
+	users, err := repo.FindAll(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Name != "John" {
+		t.Logf("Returns: %v\n", users)
+		t.Error("FindAll() result failed")
+	}
+	findExp := `SELECT "id" ,"name" FROM users WHERE id IN ($1, $2) AND (name = $3 OR name = $4);`
+	if sql, args := fake.last(); sql != findExp || !reflect.DeepEqual(args, []any{int64(1), int64(2), "John", "Jack"}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", findExp, sql, args)
+		t.Error("FindAll() failed")
+	}
+
+	repo.FindAll(database.NewQuery().And("@named").Replace("@named", "name IS NOT NULL"))
+	replaceExp := `SELECT "id" ,"name" FROM users WHERE name IS NOT NULL;`
+	if sql, _ := fake.last(); sql != replaceExp {
+		t.Logf("Expected: %s\nReturns: %s\n", replaceExp, sql)
+		t.Error("FindAll() replacements failed")
+	}
+
+	repo.NumericArgs(false).FindAll(nil)
+	allExp := `SELECT "id" ,"name" FROM users;`
+	if sql, _ := fake.last(); sql != allExp {
+		t.Logf("Expected: %s\nReturns: %s\n", allExp, sql)
+		t.Error("FindAll(nil) failed")
+	}
+
+	db, fake = newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"count"}, [][]driver.Value{{int64(3)}}
+	})
+	repo = database.NewRepository[repoUser](db, "users").NumericArgs(false)
+	count, err := repo.Count(database.NewQuery().AndEq("name", "John").AndGt("id", 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	countExp := `SELECT COUNT(*) FROM users WHERE name = ? AND id > ?;`
+	if sql, _ := fake.last(); sql != countExp || count != 3 {
+		t.Logf("Expected: %s\nReturns: %s %d\n", countExp, sql, count)
+		t.Error("Count() failed")
+	}
+}
+
+func TestRepositoryCreate(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	repo := database.NewRepository[repoUser](db, "users")
+
+	repo.Create(&repoUser{Name: "John"})
+	autoExp := `INSERT INTO users ("name") VALUES($1);`
+	if sql, args := fake.last(); sql != autoExp || !reflect.DeepEqual(args, []any{"John"}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", autoExp, sql, args)
+		t.Error("Create() zero pk failed")
+	}
+
+	repo.Create(&repoUser{Id: 5, Name: "John"})
+	keyExp := `INSERT INTO users ("id" ,"name") VALUES($1 ,$2);`
+	if sql, args := fake.last(); sql != keyExp || !reflect.DeepEqual(args, []any{int64(5), "John"}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", keyExp, sql, args)
+		t.Error("Create() pk failed")
+	}
+}
+
+func TestRepositoryTransaction(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	tx, err := db.Beginx()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	database.NewRepository[repoUser](tx, "users").Delete(1)
+	deleteExp := `DELETE FROM users WHERE "id" = $1;`
+	if sql, _ := fake.last(); sql != deleteExp {
+		t.Logf("Expected: %s\nReturns: %s\n", deleteExp, sql)
+		t.Error("Delete() in transaction failed")
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type trashedPost struct {
+	Id        int64                 `db:"id,pk"`
+	Title     string                `db:"title"`
+	DeletedAt types.Null[time.Time] `db:"deleted_at,softDelete"`
+}
+
+func TestRepositorySoftDelete(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		if strings.Contains(query, "COUNT(*)") {
+			return []string{"count"}, [][]driver.Value{{int64(1)}}
+		}
+		return []string{"id", "title", "deleted_at"}, [][]driver.Value{{int64(1), "Hello", nil}}
+	})
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repo := database.NewRepository[trashedPost](db, "posts").Clock(func() time.Time { return now })
+	query := database.NewQuery().And(`"id" > ?`, 1)
+
+	tests := []struct {
+		name string
+		run  func()
+		exp  string
+	}{
+		{"FindAll", func() { repo.FindAll(query) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE ("id" > $1) AND "deleted_at" IS NULL;`},
+		{"FindAll(nil)", func() { repo.FindAll(nil) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "deleted_at" IS NULL;`},
+		{"WithTrashed().FindAll", func() { repo.WithTrashed().FindAll(query) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" > $1;`},
+		{"OnlyTrashed().FindAll", func() { repo.OnlyTrashed().FindAll(query) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE ("id" > $1) AND "deleted_at" IS NOT NULL;`},
+		{"Count", func() { repo.Count(nil) }, `SELECT COUNT(*) FROM posts WHERE "deleted_at" IS NULL;`},
+		{"WithTrashed().Count", func() { repo.WithTrashed().Count(nil) }, `SELECT COUNT(*) FROM posts;`},
+		{"OnlyTrashed().Count", func() { repo.OnlyTrashed().Count(query) }, `SELECT COUNT(*) FROM posts WHERE ("id" > $1) AND "deleted_at" IS NOT NULL;`},
+		{"FindByID", func() { repo.FindByID(1) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1 AND "deleted_at" IS NULL;`},
+		{"WithTrashed().FindByID", func() { repo.WithTrashed().FindByID(1) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1;`},
+		{"OnlyTrashed().FindByID", func() { repo.OnlyTrashed().FindByID(1) }, `SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1 AND "deleted_at" IS NOT NULL;`},
+		{"Exists", func() { repo.Exists(1) }, `SELECT COUNT(*) FROM posts WHERE "id" = $1 AND "deleted_at" IS NULL;`},
+		{"WithTrashed().Exists", func() { repo.WithTrashed().Exists(1) }, `SELECT COUNT(*) FROM posts WHERE "id" = $1;`},
+		{"OnlyTrashed().Exists", func() { repo.OnlyTrashed().Exists(1) }, `SELECT COUNT(*) FROM posts WHERE "id" = $1 AND "deleted_at" IS NOT NULL;`},
+		{"Update", func() { repo.Update(&trashedPost{Id: 1, Title: "Hi"}) }, `UPDATE posts SET "title" = $1 WHERE "id" = $2 AND "deleted_at" IS NULL;`},
+		{"WithTrashed().Update", func() { repo.WithTrashed().Update(&trashedPost{Id: 1, Title: "Hi"}) }, `UPDATE posts SET "title" = $1 WHERE "id" = $2;`},
+		{"SoftDelete", func() { repo.SoftDelete(1) }, `UPDATE posts SET "deleted_at" = $1 WHERE "id" = $2 AND "deleted_at" IS NULL;`},
+		{"Restore", func() { repo.Restore(1) }, `UPDATE posts SET "deleted_at" = NULL WHERE "id" = $1 AND "deleted_at" IS NOT NULL;`},
+	}
+	for _, test := range tests {
+		test.run()
+		if sql, _ := fake.last(); sql != test.exp {
+			t.Logf("Expected: %s\nReturns: %s\n", test.exp, sql)
+			t.Errorf("%s() failed", test.name)
+		}
+	}
+
+	if _, args := fake.last(); !reflect.DeepEqual(args, []any{int64(1)}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("Restore() args failed")
+	}
+	repo.SoftDelete(1)
+	if _, args := fake.last(); !reflect.DeepEqual(args, []any{now, int64(1)}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("SoftDelete() args failed")
+	}
+
+	if _, err := database.NewRepository[repoUser](db, "users").SoftDelete(1); err == nil {
+		t.Error("SoftDelete() without softDelete field must fail")
+	}
+
+	database.NewRepository[unixTrashedPost](db, "posts").Clock(func() time.Time { return now }).SoftDelete(1)
+	if _, args := fake.last(); !reflect.DeepEqual(args, []any{now.Unix(), int64(1)}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("SoftDelete() unix timestamp failed")
+	}
+}
+
+type unixTrashedPost struct {
+	Id        int64 `db:"id,pk"`
+	DeletedAt int64 `db:"deleted_at,softDelete"`
+}
+
+// deleteHookCalls recorded calls of hookedPost delete hooks
+var deleteHookCalls []string
+
+type hookedPost struct {
+	Id        int64                 `db:"id,pk"`
+	Title     string                `db:"title"`
+	DeletedAt types.Null[time.Time] `db:"deleted_at,softDelete"`
+}
+
+func (post *hookedPost) BeforeDelete(ctx context.Context, db database.Executable) error {
+	deleteHookCalls = append(deleteHookCalls, "before:"+post.Title)
+	if post.Title == "locked" {
+		return errors.New("locked post")
+	}
+	return nil
+}
+
+func (post *hookedPost) AfterDelete(ctx context.Context, db database.Executable) error {
+	deleteHookCalls = append(deleteHookCalls, "after:"+post.Title)
+	return nil
+}
+
+func TestRepositoryDeleteHooks(t *testing.T) {
+	title := ""
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		if title == "" {
+			return []string{"id", "title", "deleted_at"}, nil
+		}
+		return []string{"id", "title", "deleted_at"}, [][]driver.Value{{int64(1), title, nil}}
+	})
+	repo := database.NewRepository[hookedPost](db, "posts")
+
+	tests := []struct {
+		name    string
+		title   string
+		run     func() error
+		queries []string
+		calls   []string
+	}{
+		{
+			"Delete", "Hello",
+			func() error { _, err := repo.Delete(1); return err },
+			[]string{
+				`SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1;`,
+				`DELETE FROM posts WHERE "id" = $1;`,
+			},
+			[]string{"before:Hello", "after:Hello"},
+		},
+		{
+			"SoftDelete", "Hello",
+			func() error { _, err := repo.SoftDelete(1); return err },
+			[]string{
+				`SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1 AND "deleted_at" IS NULL;`,
+				`UPDATE posts SET "deleted_at" = $1 WHERE "id" = $2 AND "deleted_at" IS NULL;`,
+			},
+			[]string{"before:Hello", "after:Hello"},
+		},
+		{
+			"Delete(locked)", "locked",
+			func() error { _, err := repo.Delete(1); return err },
+			[]string{`SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1;`},
+			[]string{"before:locked"},
+		},
+		{
+			"Delete(not found)", "",
+			func() error { _, err := repo.Delete(1); return err },
+			[]string{
+				`SELECT "id" ,"title" ,"deleted_at" FROM posts WHERE "id" = $1;`,
+				`DELETE FROM posts WHERE "id" = $1;`,
+			},
+			nil,
+		},
+	}
+	for _, test := range tests {
+		title, fake.queries, deleteHookCalls = test.title, nil, nil
+		if err := test.run(); (err != nil) != (test.title == "locked") {
+			t.Errorf("%s() error: %v", test.name, err)
+		}
+		if !reflect.DeepEqual(fake.queries, test.queries) {
+			t.Logf("Expected: %v\nReturns: %v\n", test.queries, fake.queries)
+			t.Errorf("%s() queries failed", test.name)
+		}
+		if !reflect.DeepEqual(deleteHookCalls, test.calls) {
+			t.Logf("Expected: %v\nReturns: %v\n", test.calls, deleteHookCalls)
+			t.Errorf("%s() hooks failed", test.name)
+		}
+	}
+
+	fake.queries = nil
+	database.NewRepository[repoUser](db, "users").Delete(1)
+	if len(fake.queries) != 1 {
+		t.Logf("Returns: %v\n", fake.queries)
+		t.Error("Delete() without hooks must not load record")
+	}
+}
+
+type timedUser struct {
+	Id        int       `db:"id,pk"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at,autoCreateTime"`
+	UpdatedAt time.Time `db:"updated_at,autoUpdateTime"`
+}
+
+func TestAutoTimestamp(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	db, fake := newFakeDB(nil)
+
+	user := &timedUser{Id: 1, Name: "John"}
+	insertExp := `INSERT INTO users ("id" ,"name" ,"created_at" ,"updated_at") VALUES($1 ,$2 ,$3 ,$4);`
+	database.NewInserter[*timedUser](db).
+		Table("users").
+		Clock(clock).
+		Insert(user)
+	if sql, args := fake.last(); sql != insertExp || !reflect.DeepEqual(args, []any{int64(1), "John", now, now}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", insertExp, sql, args)
+		t.Error("Insert() autoCreateTime failed")
+	}
+	if !user.CreatedAt.Equal(now) || !user.UpdatedAt.Equal(now) {
+		t.Logf("Returns: %v\n", user)
+		t.Error("Insert() must stamp entity")
+	}
+
+	user = &timedUser{Id: 1, Name: "John"}
+	updateExp := `UPDATE users SET "name" = $1 ,"updated_at" = $2 WHERE id = $3;`
+	database.NewUpdater[*timedUser](db).
+		Table("users").
+		Clock(clock).
+		Where("id = ?", 1).
+		Update(user)
+	if sql, args := fake.last(); sql != updateExp || !reflect.DeepEqual(args, []any{"John", now, int64(1)}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", updateExp, sql, args)
+		t.Error("Update() autoUpdateTime failed")
+	}
+	if !user.UpdatedAt.Equal(now) || !user.CreatedAt.IsZero() {
+		t.Logf("Returns: %v\n", user)
+		t.Error("Update() must stamp entity")
+	}
+
+	// value entity
+	fake.queries = nil
+	if _, err := database.NewInserter[timedUser](db).Table("users").Insert(timedUser{Name: "John"}); err != database.ErrValueEntity {
+		t.Error("Insert() value entity must fail")
+	}
+	if _, err := database.NewUpdater[timedUser](db).Table("users").Where("id = ?", 1).Update(timedUser{Id: 1}); err != database.ErrValueEntity {
+		t.Error("Update() value entity must fail")
+	}
+	if len(fake.queries) != 0 {
+		t.Logf("Returns: %v\n", fake.queries)
+		t.Error("value entity must not executed")
+	}
+
+	created := &timedUser{Name: "John"}
+	database.NewRepository[timedUser](db, "users").Clock(clock).Create(created)
+	if !created.CreatedAt.Equal(now) {
+		t.Logf("Returns: %v\n", created)
+		t.Error("Create() must stamp entity")
+	}
+}
+
+type versionedPost struct {
+	Id      int    `db:"id,pk"`
+	Title   string `db:"title"`
+	Version int    `db:"version,version"`
+}
+
+func TestOptimisticLock(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	fake.affected = 0
+	post := &versionedPost{Id: 1, Title: "Hello", Version: 3}
+
+	updateExp := `UPDATE posts SET "title" = $1 ,"version" = "version" + 1 WHERE (id = $2) AND "version" = $3;`
+	_, err := database.NewUpdater[*versionedPost](db).
+		Table("posts").
+		Where("id = ?", 1).
+		Update(post)
+	if sql, args := fake.last(); sql != updateExp || !reflect.DeepEqual(args, []any{"Hello", int64(1), int64(3)}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", updateExp, sql, args)
+		t.Error("Update() version failed")
+	}
+	if err != database.ErrStaleEntity {
+		t.Error("Update() must return ErrStaleEntity")
+	}
+
+	fake.affected = 1
+	if _, err := database.NewUpdater[*versionedPost](db).
+		Table("posts").
+		Where("id = ?", 1).
+		Update(post); err != nil || post.Version != 4 {
+		t.Error("Update() version increment failed")
+	}
+
+	fake.queries = nil
+	if _, err := database.NewUpdater[versionedPost](db).
+		Table("posts").
+		Where("id = ?", 1).
+		Update(*post); err != database.ErrValueEntity || len(fake.queries) != 0 {
+		t.Error("Update() value versioned entity must fail")
+	}
+}
+
+type hookedUser struct {
+	Id   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (user *hookedUser) BeforeInsert(ctx context.Context, db database.Executable) error {
+	if user.Name == "" {
+		return errors.New("name required")
+	}
+	user.Name = strings.ToLower(user.Name)
+	return nil
+}
+
+func TestInsertHooks(t *testing.T) {
+	db, fake := newFakeDB(nil)
+	inserter := database.NewInserter[*hookedUser](db).Table("users")
+
+	if _, err := inserter.Insert(&hookedUser{Id: 1}); err == nil {
+		t.Error("BeforeInsert() error ignored")
+	}
+
+	user := &hookedUser{Id: 1, Name: "JOHN"}
+	if _, err := inserter.Insert(user); err != nil {
+		t.Error(err)
+	} else if _, args := fake.last(); !reflect.DeepEqual(args, []any{int64(1), "john"}) || user.Name != "john" {
+		t.Logf("Returns: %v %v\n", args, user)
+		t.Error("BeforeInsert() failed")
+	}
+
+	fake.queries = nil
+	if _, err := database.NewInserter[hookedUser](db).Table("users").Insert(*user); err != database.ErrValueEntity || len(fake.queries) != 0 {
+		t.Error("Insert() value entity with hooks must fail")
+	}
+}
+
+type validatedUser struct {
+	Id    int                `db:"id,pk"`
+	Name  string             `db:"name" validate:"required,max=5"`
+	Role  string             `db:"role" validate:"oneof=admin user"`
+	Phone types.Null[string] `db:"phone" validate:"min=3"`
+}
+
+func TestValidation(t *testing.T) {
+	db, _ := newFakeDB(nil)
+	inserter := database.NewInserter[validatedUser](db).Table("users")
+
+	_, err := inserter.Insert(validatedUser{Name: "Jonathan", Role: "guest"})
+	var vErr *database.ValidationError
+	if !errors.As(err, &vErr) {
+		t.Fatal("Insert() validation failed")
+	}
+	if len(vErr.Fields) != 2 || vErr.Fields[0].Column != "name" || vErr.Fields[1].Rule != "oneof" {
+		t.Logf("Returns: %v\n", vErr)
+		t.Error("Insert() validation fields failed")
+	}
+
+	if _, err := inserter.Insert(validatedUser{Name: "John", Role: "user"}); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,10 +1,17 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"reflect"
 	"strings"
+	"time"
 )
 
+// ErrStaleEntity returned when versioned entity changed by another update
+var ErrStaleEntity = errors.New("stale entity")
+
 type Updater[T any] interface {
 	// NumericArgs specifies whether to use numeric ($1, $2) or normal (?, ?) placeholder
 	NumericArgs(isNumeric bool) Updater[T]
@@ -14,7 +21,23 @@ type Updater[T any] interface {
 	Table(table string) Updater[T]
 	// Where update condition
 	Where(cond string, args ...any) Updater[T]
+	// Context set context passed to IBeforeUpdate and IAfterUpdate hooks
+	Context(ctx context.Context) Updater[T]
+	// Clock set current time resolver for autoUpdateTime fields
+	Clock(clock func() time.Time) Updater[T]
 	// Update update and return result
+	//
+	// fields tagged with pk, autoCreateTime or softDelete option not updated,
+	// fields tagged with autoUpdateTime option filled with current time
+	//
+	// field tagged with version option used for optimistic locking,
+	// returns ErrStaleEntity if no rows affected,
+	// versioned entity must be pointer to receive incremented version, otherwise ErrValueEntity returned
+	//
+	// IBeforeUpdate and IAfterUpdate hooks called if implemented by entity,
+	// entity with hooks must be pointer, otherwise ErrValueEntity returned
+	//
+	// updated fields validated by `validate` tag rules and IValidator before update
 	Update(entity T) (sql.Result, error)
 }
 
@@ -23,6 +46,8 @@ func NewUpdater[T any](db Executable) Updater[T] {
 	updater.db = db
 	updater.numeric = true
 	updater.quoted = true
+	updater.ctx = context.Background()
+	updater.clock = time.Now
 	return updater
 }
 
@@ -33,6 +58,8 @@ type updaterDriver[T any] struct {
 	table     string
 	condition string
 	args      []any
+	ctx       context.Context
+	clock     func() time.Time
 }
 
 func (updater *updaterDriver[T]) NumericArgs(numeric bool) Updater[T] {
@@ -56,21 +83,112 @@ func (updater *updaterDriver[T]) Where(cond string, args ...any) Updater[T] {
 	return updater
 }
 
+func (updater *updaterDriver[T]) Context(ctx context.Context) Updater[T] {
+	if ctx != nil {
+		updater.ctx = ctx
+	}
+	return updater
+}
+
+func (updater *updaterDriver[T]) Clock(clock func() time.Time) Updater[T] {
+	if clock != nil {
+		updater.clock = clock
+	}
+	return updater
+}
+
 func (updater *updaterDriver[T]) Update(entity T) (sql.Result, error) {
-	fields := structColumns(entity, updater.quoted)
-	for i, v := range fields {
-		fields[i] = v + " = ?"
+	val := indirect(&entity)
+	fields := make([]string, 0)
+	values := make([]any, 0)
+	condition := updater.condition
+	args := updater.args
+	var version *reflect.Value
+	if val.Kind() == reflect.Struct {
+		_, before := val.Addr().Interface().(IBeforeUpdate)
+		_, after := val.Addr().Interface().(IAfterUpdate)
+		if (before || after) && !isPointer(entity) {
+			return nil, ErrValueEntity
+		}
+		if hook, ok := val.Addr().Interface().(IBeforeUpdate); ok {
+			if err := hook.BeforeUpdate(updater.ctx, updater.db); err != nil {
+				return nil, err
+			}
+		}
+
+		now := updater.clock()
+		written := make([]structField, 0)
+		for _, field := range structFields(val.Type()) {
+			if field.Has("nested") {
+				continue
+			}
+
+			column := quoteField(field.Column, updater.quoted)
+			if field.Has("version") {
+				if !isPointer(entity) {
+					return nil, ErrValueEntity
+				}
+				v := val.Field(field.Index)
+				version = &v
+				fields = append(fields, column+" = "+column+" + 1")
+				condition = "(" + condition + ") AND " + column + " = ?"
+				args = append(append([]any{}, args...), v.Interface())
+				continue
+			}
+			if field.Has("autoUpdateTime") {
+				if !isPointer(entity) {
+					return nil, ErrValueEntity
+				}
+				setTime(val.Field(field.Index), now)
+			}
+			if !field.Has("pk") && !field.Has("autoCreateTime") && !field.Has("softDelete") {
+				written = append(written, field)
+				fields = append(fields, column+" = ?")
+				values = append(values, val.Field(field.Index).Interface())
+			}
+		}
+
+		if err := validateStruct(val, written); err != nil {
+			return nil, err
+		}
 	}
 
 	sql := strings.NewReplacer(
 		"@table", updater.table,
-		"@cond", updater.condition,
+		"@cond", condition,
 		"@fields", strings.Join(fields, " ,"),
 	).Replace("UPDATE @table SET @fields WHERE @cond;")
 
-	if updater.numeric {
-		sql = numericArgs(sql, 1)
+	sql = compileQuery(sql, nil, updater.numeric)
+
+	result, err := updater.db.Exec(sql, append(values, args...)...)
+	if err != nil {
+		return result, err
 	}
 
-	return updater.db.Exec(sql, append(structValues(entity), updater.args...)...)
+	if version != nil {
+		if affected, err := result.RowsAffected(); err != nil {
+			return result, err
+		} else if affected == 0 {
+			return result, ErrStaleEntity
+		}
+
+		if version.CanSet() {
+			switch version.Kind() {
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				version.SetInt(version.Int() + 1)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				version.SetUint(version.Uint() + 1)
+			}
+		}
+	}
+
+	if val.Kind() == reflect.Struct {
+		if hook, ok := val.Addr().Interface().(IAfterUpdate); ok {
+			if err := hook.AfterUpdate(updater.ctx, updater.db); err != nil {
+				return result, err
+			}
+		}
+	}
+	return result, nil
 }
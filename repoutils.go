@@ -1,12 +1,22 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
+
+	"github.com/gomig/database/v2/types"
+	"github.com/jmoiron/sqlx"
 )
 
+// ErrValueEntity returned when entity changed by insert or update passed by value,
+// e.g. use Inserter[*User] instead of Inserter[User] for entities with auto timestamp fields
+var ErrValueEntity = errors.New("entity must be passed by pointer")
+
 type IDecoder interface {
 	Decode() error
 }
@@ -15,6 +25,178 @@ type Executable interface {
 	Exec(string, ...any) (sql.Result, error)
 }
 
+// Queryable database or transaction to run queries and commands, e.g. *sqlx.DB or *sqlx.Tx
+type Queryable interface {
+	sqlx.Queryer
+	Executable
+}
+
+// IBeforeInsert called by Inserter before generating insert command
+type IBeforeInsert interface {
+	BeforeInsert(ctx context.Context, db Executable) error
+}
+
+// IAfterInsert called by Inserter after successful insert
+type IAfterInsert interface {
+	AfterInsert(ctx context.Context, db Executable) error
+}
+
+// IBeforeUpdate called by Updater before generating update command
+type IBeforeUpdate interface {
+	BeforeUpdate(ctx context.Context, db Executable) error
+}
+
+// IAfterUpdate called by Updater after successful update
+type IAfterUpdate interface {
+	AfterUpdate(ctx context.Context, db Executable) error
+}
+
+// IBeforeDelete called by Repository before delete or soft delete command
+type IBeforeDelete interface {
+	BeforeDelete(ctx context.Context, db Executable) error
+}
+
+// IAfterDelete called by Repository after successful delete or soft delete
+type IAfterDelete interface {
+	AfterDelete(ctx context.Context, db Executable) error
+}
+
+// structField `db` tagged field of struct
+type structField struct {
+	Index   int
+	Name    string
+	Column  string
+	Options []string
+}
+
+// Has check if field tag contains option
+func (field structField) Has(option string) bool {
+	for _, opt := range field.Options {
+		if strings.EqualFold(opt, option) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTag split tag to name and options list
+func parseTag(tag string) (string, []string) {
+	parts := strings.Split(tag, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+	return parts[0], parts[1:]
+}
+
+// quoteField quote field name if quoted
+func quoteField(field string, quoted bool) string {
+	if quoted {
+		return `"` + field + `"`
+	}
+	return field
+}
+
+// indirect get underlying value of pointer
+func indirect(v any) reflect.Value {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Pointer && !val.IsNil() {
+		val = val.Elem()
+	}
+	return val
+}
+
+// isPointer check if entity passed by pointer, changes of value entity lost after insert or update
+func isPointer(entity any) bool {
+	return reflect.ValueOf(entity).Kind() == reflect.Pointer
+}
+
+// underlyingValue resolve underlying value of pointer, nullable and slice types
+//
+// returns false if value is nil or null
+func underlyingValue(val reflect.Value) (reflect.Value, bool) {
+	for val.Kind() == reflect.Pointer || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return val, false
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() == reflect.Struct {
+		if valid := val.FieldByName("Valid"); valid.IsValid() && valid.Kind() == reflect.Bool && val.NumField() == 2 {
+			if !valid.Bool() {
+				return val, false
+			}
+			for i := 0; i < val.NumField(); i++ {
+				if val.Type().Field(i).Name != "Valid" {
+					return val.Field(i), true
+				}
+			}
+		} else if val.NumField() == 1 && val.Field(0).Kind() == reflect.Slice {
+			return val.Field(0), !val.Field(0).IsNil()
+		}
+	}
+	return val, true
+}
+
+// structFields get exported struct fields where `db` tag not - or empty
+func structFields(typ reflect.Type) []structField {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	res := make([]structField, 0)
+	if typ.Kind() != reflect.Struct {
+		return res
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		if typ.Field(i).IsExported() {
+			if tag, ok := typ.Field(i).Tag.Lookup("db"); ok {
+				if name, options := parseTag(tag); name != "-" && name != "" {
+					res = append(res, structField{
+						Index:   i,
+						Name:    typ.Field(i).Name,
+						Column:  name,
+						Options: options,
+					})
+				}
+			}
+		}
+	}
+	return res
+}
+
+// primaryFields get fields with pk option
+func primaryFields(typ reflect.Type) []structField {
+	res := make([]structField, 0)
+	for _, field := range structFields(typ) {
+		if field.Has("pk") {
+			res = append(res, field)
+		}
+	}
+	return res
+}
+
+// setTime set time to time.Time, *time.Time, sql.NullTime, types.NullTime,
+// types.Null[time.Time] or int64 (unix timestamp) field
+func setTime(field reflect.Value, t time.Time) {
+	if !field.CanSet() {
+		return
+	}
+	switch field.Interface().(type) {
+	case time.Time:
+		field.Set(reflect.ValueOf(t))
+	case *time.Time:
+		field.Set(reflect.ValueOf(&t))
+	case sql.NullTime:
+		field.Set(reflect.ValueOf(sql.NullTime{Time: t, Valid: true}))
+	case types.NullTime:
+		field.Set(reflect.ValueOf(types.NullTime{Time: t, Valid: true}))
+	case types.Null[time.Time]:
+		field.Set(reflect.ValueOf(types.NullOf(t)))
+	case int64:
+		field.SetInt(t.Unix())
+	}
+}
+
 // structQueryColumns get columns list from `q` or `db` struct tag
 func structQueryColumns(v any, quoted bool) []string {
 	val := reflect.ValueOf(v)
@@ -33,20 +215,22 @@ func structQueryColumns(v any, quoted bool) []string {
 			if typ.Field(i).IsExported() {
 				if typ.Field(i).Anonymous {
 					res = append(res, structQueryColumns(val.Field(i).Interface(), quoted)...)
+				} else if name, nested := nestedField(typ.Field(i)); nested {
+					table := name
+					if q := typ.Field(i).Tag.Get("q"); q == "-" {
+						continue
+					} else if q != "" {
+						table = q
+					}
+					res = append(res, nestedQueryColumns(typ.Field(i).Type, table, name, quoted)...)
 				} else {
 					if q, ok := typ.Field(i).Tag.Lookup("q"); ok {
 						if q != "-" && q != "" {
-							if quoted {
-								res = append(res, `"`+q+`"`)
-							} else {
-								res = append(res, q)
-							}
+							res = append(res, quoteField(q, quoted))
 						}
-					} else if tag, ok := typ.Field(i).Tag.Lookup("db"); ok && tag != "-" && tag != "" {
-						if quoted {
-							res = append(res, `"`+tag+`"`)
-						} else {
-							res = append(res, tag)
+					} else if tag, ok := typ.Field(i).Tag.Lookup("db"); ok {
+						if name, _ := parseTag(tag); name != "-" && name != "" {
+							res = append(res, quoteField(name, quoted))
 						}
 					}
 				}
@@ -56,72 +240,138 @@ func structQueryColumns(v any, quoted bool) []string {
 	}
 }
 
-// structColumns get columns list from `db` struct tag
-func structColumns(v any, quoted bool) []string {
-	val := reflect.ValueOf(v)
-	if val.Kind() == reflect.Pointer {
-		if val.IsNil() || !val.Elem().CanInterface() {
-			return []string{}
-		} else {
-			return structColumns(val.Elem().Interface(), quoted)
+// nestedField get prefix of struct field tagged with nested option, e.g. `db:"profile,nested"`
+func nestedField(field reflect.StructField) (string, bool) {
+	if tag, ok := field.Tag.Lookup("db"); ok {
+		name, options := parseTag(tag)
+		if name != "-" && name != "" && (structField{Options: options}).Has("nested") {
+			return name, true
 		}
-	} else if val.Kind() != reflect.Struct {
-		return []string{}
-	} else {
-		res := make([]string, 0)
-		typ := reflect.TypeOf(v)
-		for i := 0; i < typ.NumField(); i++ {
-			if typ.Field(i).IsExported() {
-				if tag, ok := typ.Field(i).Tag.Lookup("db"); ok && tag != "-" && tag != "" {
-					if quoted {
-						res = append(res, `"`+tag+`"`)
-					} else {
-						res = append(res, tag)
-					}
-				}
+	}
+	return "", false
+}
+
+// nestedQueryColumns get columns list of nested struct in `table.column AS "prefix.column"` format
+func nestedQueryColumns(typ reflect.Type, table, prefix string, quoted bool) []string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	res := make([]string, 0)
+	if typ.Kind() != reflect.Struct {
+		return res
+	}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() || field.Tag.Get("q") == "-" {
+			continue
+		}
+		if name, nested := nestedField(field); nested {
+			child := name
+			if q := field.Tag.Get("q"); q != "" {
+				child = q
+			}
+			res = append(res, nestedQueryColumns(field.Type, child, prefix+"."+name, quoted)...)
+		} else if tag, ok := field.Tag.Lookup("db"); ok {
+			if name, _ := parseTag(tag); name != "-" && name != "" {
+				res = append(res, quoteField(table, quoted)+"."+quoteField(name, quoted)+` AS "`+prefix+"."+name+`"`)
 			}
 		}
-		return res
 	}
+	return res
 }
 
-// structValues get struct value where `db` tag not - or empty
-func structValues(v any) []any {
-	val := reflect.ValueOf(v)
-	if val.Kind() == reflect.Pointer {
-		if val.IsNil() || !val.Elem().CanInterface() {
-			return []any{}
-		} else {
-			return structValues(val.Elem().Interface())
+// compileQuery apply replacements to query and convert placeholder to numeric in numeric mode
+//
+// query already contains numeric placeholder (e.g. Raw or SQL of numeric query builder) not numbered again
+//
+// must called once right before execution
+func compileQuery(query string, replacements []string, numeric bool) string {
+	query = strings.NewReplacer(replacements...).Replace(query)
+	if numeric && !hasNumericArgs(query) {
+		query = numericArgs(query, 1)
+	}
+	return query
+}
+
+// hasNumericArgs check if query contains numeric ($1) placeholder outside quoted literals
+func hasNumericArgs(query string) bool {
+	found := false
+	scanQuery(query, func(part string) string {
+		for i := 0; i+1 < len(part) && !found; i++ {
+			found = part[i] == '$' && part[i+1] >= '0' && part[i+1] <= '9'
 		}
-	} else if val.Kind() != reflect.Struct {
-		return []any{}
+		return part
+	})
+	return found
+}
+
+// mergeArgs get new arguments list of base and args
+func mergeArgs(base []any, args ...any) []any {
+	res := make([]any, 0, len(base)+len(args))
+	res = append(res, base...)
+	return append(res, args...)
+}
+
+// scanQuery call visit on sql parts of query outside quoted literals and identifiers, quoted parts kept as is
+func scanQuery(query string, visit func(part string) string) string {
+	var res strings.Builder
+	start := 0
+	var quote rune
+	for i, char := range query {
+		switch {
+		case quote != 0:
+			if char == quote {
+				res.WriteString(query[start : i+1])
+				start, quote = i+1, 0
+			}
+		case char == '\'' || char == '"' || char == '`':
+			res.WriteString(visit(query[start:i]))
+			start, quote = i, char
+		}
+	}
+	if quote != 0 {
+		res.WriteString(query[start:])
 	} else {
-		res := make([]any, 0)
-		typ := reflect.TypeOf(v)
-		for i := 0; i < typ.NumField(); i++ {
-			if typ.Field(i).IsExported() {
-				if tag, ok := typ.Field(i).Tag.Lookup("db"); ok && tag != "-" && tag != "" {
-					res = append(res, val.Field(i).Interface())
+		res.WriteString(visit(query[start:]))
+	}
+	return res.String()
+}
+
+// replacePlaceholders replace normal (?) placeholders of query with replace result
+//
+// placeholders inside quoted literals and escaped ?? ignored, escaped ?? converted to literal ? if unescape
+func replacePlaceholders(query string, unescape bool, replace func(index int) string) string {
+	index := 0
+	return scanQuery(query, func(part string) string {
+		var res strings.Builder
+		for i := 0; i < len(part); i++ {
+			switch {
+			case part[i] != '?':
+				res.WriteByte(part[i])
+			case i+1 < len(part) && part[i+1] == '?':
+				if unescape {
+					res.WriteString("?")
+				} else {
+					res.WriteString("??")
 				}
+				i++
+			default:
+				res.WriteString(replace(index))
+				index++
 			}
 		}
-		return res
-	}
+		return res.String()
+	})
 }
 
 // numericArgs convert ? placeholder to numeric $1 placeholder
+//
+// escaped ?? (e.g. postgres jsonb ? operator) converted to literal ?
 func numericArgs(query string, counter int) string {
 	if counter <= 0 {
 		counter = 1
 	}
-	for {
-		if strings.Contains(query, "?") {
-			query = strings.Replace(query, "?", fmt.Sprintf("$%d", counter), 1)
-			counter++
-		} else {
-			break
-		}
-	}
-	return query
+	return replacePlaceholders(query, true, func(index int) string {
+		return fmt.Sprintf("$%d", counter+index)
+	})
 }
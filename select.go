@@ -0,0 +1,66 @@
+package database
+
+// Select query builder
+type SelectBuilder interface {
+	// Dialect set sql dialect, Postgres by default
+	Dialect(dialect Dialect) SelectBuilder
+	// With add common table expression, name can contains columns list, e.g. "tree(id, parent_id)"
+	With(name string, query SelectBuilder) SelectBuilder
+	// WithRecursive add recursive common table expression
+	WithRecursive(name string, query SelectBuilder) SelectBuilder
+	// Distinct select distinct rows
+	Distinct() SelectBuilder
+	// From set table to select from
+	From(table string) SelectBuilder
+	// Columns add columns to select, all columns (*) selected if not set
+	Columns(columns ...string) SelectBuilder
+	// Join add INNER JOIN with on condition
+	Join(table string, on string, args ...any) SelectBuilder
+	// LeftJoin add LEFT JOIN with on condition
+	LeftJoin(table string, on string, args ...any) SelectBuilder
+	// Where set where condition
+	Where(query QueryBuilder) SelectBuilder
+	// GroupBy add group by columns
+	GroupBy(columns ...string) SelectBuilder
+	// Having set having condition
+	Having(query QueryBuilder) SelectBuilder
+	// Union combine result with other select using UNION
+	Union(query SelectBuilder) SelectBuilder
+	// UnionAll combine result with other select using UNION ALL
+	UnionAll(query SelectBuilder) SelectBuilder
+	// Intersect combine result with other select using INTERSECT
+	Intersect(query SelectBuilder) SelectBuilder
+	// Except combine result with other select using EXCEPT
+	Except(query SelectBuilder) SelectBuilder
+	// OrderBy add order by columns, e.g. "name ASC"
+	//
+	// order, limit and offset applied to combined result of Union, UnionAll, Intersect and Except
+	OrderBy(columns ...string) SelectBuilder
+	// OrderByRank add full-text rank of columns to order by, most relevant first
+	//
+	// ignored if input has no word, rank generated with builder dialect on render
+	OrderByRank(language string, input string, columns ...string) SelectBuilder
+	// Sort add order by columns of parsed sorter
+	Sort(sorter Sorter) SelectBuilder
+	// Limit set result limit
+	Limit(limit int) SelectBuilder
+	// Offset set result offset
+	Offset(offset int) SelectBuilder
+	// SQL get generated sql with dialect placeholder
+	SQL() string
+	// Args get list of arguments
+	Args() []any
+	// Debug get generated sql with arguments quoted for dialect
+	//
+	// result is for logging and debugging only and must not be executed
+	Debug() string
+}
+
+// NewSelect generate new select query builder
+func NewSelect() SelectBuilder {
+	res := new(sBuilder)
+	res.dialect = Postgres
+	res.limit = -1
+	res.offset = -1
+	return res
+}
@@ -0,0 +1,358 @@
+package database
+
+import (
+	"strconv"
+	"strings"
+)
+
+type sJoin struct {
+	Type  string
+	Table string
+	On    string
+	Args  []any
+}
+
+// sOrder order column or full-text rank of columns, rank generated with builder dialect on render
+type sOrder struct {
+	Column   string
+	Language string
+	Input    string
+	Rank     []string
+}
+
+// sPart common table expression (cte name) or combined select (operator name)
+type sPart struct {
+	Name  string
+	Query SelectBuilder
+}
+
+type sBuilder struct {
+	dialect   Dialect
+	withs     []sPart
+	recursive bool
+	compounds []sPart
+	distinct  bool
+	table     string
+	columns   []string
+	joins     []sJoin
+	where     QueryBuilder
+	groups    []string
+	having    QueryBuilder
+	orders    []sOrder
+	limit     int
+	offset    int
+}
+
+func (builder *sBuilder) Dialect(dialect Dialect) SelectBuilder {
+	builder.dialect = dialect
+	return builder
+}
+
+func (builder *sBuilder) With(name string, query SelectBuilder) SelectBuilder {
+	if query != nil {
+		builder.withs = append(builder.withs, sPart{name, query})
+	}
+	return builder
+}
+
+func (builder *sBuilder) WithRecursive(name string, query SelectBuilder) SelectBuilder {
+	if query != nil {
+		builder.recursive = true
+		builder.withs = append(builder.withs, sPart{name, query})
+	}
+	return builder
+}
+
+func (builder *sBuilder) Distinct() SelectBuilder {
+	builder.distinct = true
+	return builder
+}
+
+func (builder *sBuilder) From(table string) SelectBuilder {
+	builder.table = table
+	return builder
+}
+
+func (builder *sBuilder) Columns(columns ...string) SelectBuilder {
+	builder.columns = append(builder.columns, columns...)
+	return builder
+}
+
+func (builder *sBuilder) Join(table string, on string, args ...any) SelectBuilder {
+	builder.joins = append(builder.joins, sJoin{"JOIN", table, on, args})
+	return builder
+}
+
+func (builder *sBuilder) LeftJoin(table string, on string, args ...any) SelectBuilder {
+	builder.joins = append(builder.joins, sJoin{"LEFT JOIN", table, on, args})
+	return builder
+}
+
+func (builder *sBuilder) Where(query QueryBuilder) SelectBuilder {
+	builder.where = query
+	return builder
+}
+
+func (builder *sBuilder) GroupBy(columns ...string) SelectBuilder {
+	builder.groups = append(builder.groups, columns...)
+	return builder
+}
+
+func (builder *sBuilder) Having(query QueryBuilder) SelectBuilder {
+	builder.having = query
+	return builder
+}
+
+func (builder *sBuilder) addCompound(typ string, query SelectBuilder) {
+	if query != nil {
+		builder.compounds = append(builder.compounds, sPart{typ, query})
+	}
+}
+
+func (builder *sBuilder) Union(query SelectBuilder) SelectBuilder {
+	builder.addCompound("UNION", query)
+	return builder
+}
+
+func (builder *sBuilder) UnionAll(query SelectBuilder) SelectBuilder {
+	builder.addCompound("UNION ALL", query)
+	return builder
+}
+
+func (builder *sBuilder) Intersect(query SelectBuilder) SelectBuilder {
+	builder.addCompound("INTERSECT", query)
+	return builder
+}
+
+func (builder *sBuilder) Except(query SelectBuilder) SelectBuilder {
+	builder.addCompound("EXCEPT", query)
+	return builder
+}
+
+func (builder *sBuilder) OrderBy(columns ...string) SelectBuilder {
+	for _, column := range columns {
+		builder.orders = append(builder.orders, sOrder{Column: column})
+	}
+	return builder
+}
+
+func (builder *sBuilder) Sort(sorter Sorter) SelectBuilder {
+	if sorter != nil {
+		builder.OrderBy(sorter.Columns()...)
+	}
+	return builder
+}
+
+func (builder *sBuilder) OrderByRank(language string, input string, columns ...string) SelectBuilder {
+	if searchable(input, columns) {
+		builder.orders = append(builder.orders, sOrder{
+			Language: language,
+			Input:    input,
+			Rank:     append([]string{}, columns...),
+		})
+	}
+	return builder
+}
+
+func (builder *sBuilder) Limit(limit int) SelectBuilder {
+	builder.limit = limit
+	return builder
+}
+
+func (builder *sBuilder) Offset(offset int) SelectBuilder {
+	builder.offset = offset
+	return builder
+}
+
+// renderSelect generate select without order, limit and offset
+func (builder *sBuilder) renderSelect() (string, []any) {
+	args := make([]any, 0)
+	parts := []string{"SELECT"}
+	if builder.distinct {
+		parts = append(parts, "DISTINCT")
+	}
+
+	if len(builder.columns) == 0 {
+		parts = append(parts, "*")
+	} else {
+		parts = append(parts, strings.Join(builder.columns, ", "))
+	}
+
+	if builder.table != "" {
+		parts = append(parts, "FROM "+builder.table)
+	}
+
+	for _, join := range builder.joins {
+		on, onArgs := renderSubqueries(join.On, join.Args, builder.dialect)
+		parts = append(parts, join.Type+" "+join.Table+" ON "+on)
+		args = append(args, onArgs...)
+	}
+
+	if builder.where != nil {
+		if where, whereArgs := renderQuery(builder.where, builder.dialect); where != "" {
+			parts = append(parts, "WHERE "+where)
+			args = append(args, whereArgs...)
+		}
+	}
+
+	if len(builder.groups) > 0 {
+		parts = append(parts, "GROUP BY "+strings.Join(builder.groups, ", "))
+	}
+
+	if builder.having != nil {
+		if having, havingArgs := renderQuery(builder.having, builder.dialect); having != "" {
+			parts = append(parts, "HAVING "+having)
+			args = append(args, havingArgs...)
+		}
+	}
+
+	return strings.Join(parts, " "), args
+}
+
+// renderPagination generate order, limit and offset
+func (builder *sBuilder) renderPagination() (string, []any) {
+	parts := make([]string, 0)
+	args := make([]any, 0)
+	if len(builder.orders) > 0 {
+		orders := make([]string, 0, len(builder.orders))
+		for _, order := range builder.orders {
+			if len(order.Rank) == 0 {
+				orders = append(orders, order.Column)
+				continue
+			}
+			rank, rankArgs := searchRank(builder.dialect, order.Language, order.Input, order.Rank)
+			orders = append(orders, rank+" DESC")
+			args = append(args, rankArgs...)
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(orders, ", "))
+	}
+
+	if builder.limit >= 0 {
+		parts = append(parts, "LIMIT "+strconv.Itoa(builder.limit))
+	} else if builder.offset >= 0 && builder.dialect == MySQL {
+		// mysql not support offset without limit
+		parts = append(parts, "LIMIT 18446744073709551615")
+	}
+
+	if builder.offset >= 0 {
+		parts = append(parts, "OFFSET "+strconv.Itoa(builder.offset))
+	}
+	return strings.Join(parts, " "), args
+}
+
+// renderWith generate common table expressions
+func (builder *sBuilder) renderWith() (string, []any) {
+	if len(builder.withs) == 0 {
+		return "", nil
+	}
+
+	args := make([]any, 0)
+	parts := make([]string, 0, len(builder.withs))
+	for _, with := range builder.withs {
+		query, queryArgs := renderSelect(with.Query)
+		parts = append(parts, with.Name+" AS ("+query+")")
+		args = append(args, queryArgs...)
+	}
+
+	if builder.recursive {
+		return "WITH RECURSIVE " + strings.Join(parts, ", "), args
+	}
+	return "WITH " + strings.Join(parts, ", "), args
+}
+
+// renderCompound generate select combined with union, intersect and except selects
+func (builder *sBuilder) renderCompound() (string, []any) {
+	command, args := builder.renderSelect()
+	for _, compound := range builder.compounds {
+		query, queryArgs := renderSelect(compound.Query)
+		if b, ok := compound.Query.(*sBuilder); !ok || !b.simple() {
+			query = "(" + query + ")"
+		}
+		command = command + " " + compound.Name + " " + query
+		args = append(args, queryArgs...)
+	}
+	return command, args
+}
+
+// simple check if select can combined without nested ()
+func (builder *sBuilder) simple() bool {
+	return len(builder.withs) == 0 &&
+		len(builder.compounds) == 0 &&
+		len(builder.orders) == 0 &&
+		builder.limit < 0 &&
+		builder.offset < 0
+}
+
+// replace apply Replace phrases of where and having query builders on generated sql
+func (builder *sBuilder) replace(command string) string {
+	replacements := make([]string, 0)
+	if builder.where != nil {
+		replacements = append(replacements, queryReplacements(builder.where)...)
+	}
+	if builder.having != nil {
+		replacements = append(replacements, queryReplacements(builder.having)...)
+	}
+	if len(replacements) == 0 {
+		return command
+	}
+	return strings.NewReplacer(replacements...).Replace(command)
+}
+
+// render generate query with normal (?) placeholder and arguments list
+func (builder *sBuilder) render() (string, []any) {
+	command, args := builder.renderCompound()
+	if pagination, paginationArgs := builder.renderPagination(); pagination != "" {
+		command = command + " " + pagination
+		args = append(args, paginationArgs...)
+	}
+	if with, withArgs := builder.renderWith(); with != "" {
+		command = with + " " + command
+		args = mergeArgs(withArgs, args...)
+	}
+	return builder.replace(command), args
+}
+
+// renderCount generate count query of select
+func (builder *sBuilder) renderCount() (string, []any) {
+	command, args := builder.renderCompound()
+	command = "SELECT COUNT(*) FROM (" + command + ") AS count_query"
+	if with, withArgs := builder.renderWith(); with != "" {
+		command = with + " " + command
+		args = mergeArgs(withArgs, args...)
+	}
+	return builder.replace(command), args
+}
+
+func (builder *sBuilder) SQL() string {
+	command, _ := builder.render()
+	if builder.dialect.numeric() {
+		command = numericArgs(command, 1)
+	}
+	return command
+}
+
+func (builder *sBuilder) Args() []any {
+	_, args := builder.render()
+	return args
+}
+
+func (builder *sBuilder) Debug() string {
+	command, args := builder.render()
+	return debugComment + interpolate(command, args, builder.dialect)
+}
+
+// renderSelect get select builder sql with normal (?) placeholder and arguments
+func renderSelect(builder SelectBuilder) (string, []any) {
+	if b, ok := builder.(*sBuilder); ok {
+		return b.render()
+	}
+	return builder.SQL(), builder.Args()
+}
+
+// renderSelectCount get count sql of select builder with normal (?) placeholder and arguments
+func renderSelectCount(builder SelectBuilder) (string, []any) {
+	if b, ok := builder.(*sBuilder); ok {
+		return b.renderCount()
+	}
+	return "SELECT COUNT(*) FROM (" + builder.SQL() + ") AS count_query", builder.Args()
+}
@@ -0,0 +1,152 @@
+package database_test
+
+import (
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/gomig/database/v2"
+)
+
+func TestSelectBuilder(t *testing.T) {
+	pgExp := `SELECT DISTINCT u.id, u.name FROM users u JOIN roles r ON r.id = u.role_id AND r.active = $1 WHERE u.age > $2 AND r.name IN ($3, $4) GROUP BY u.id, u.name HAVING COUNT(*) > $5 ORDER BY u.name ASC LIMIT 10 OFFSET 20`
+	pgQ := database.NewSelect().
+		Distinct().
+		Columns("u.id", "u.name").
+		From("users u").
+		Join("roles r", "r.id = u.role_id AND r.active = ?", true).
+		Where(database.NewQuery().And("u.age > ?", 18).And("r.name @in", "admin", "user")).
+		GroupBy("u.id", "u.name").
+		Having(database.NewQuery().And("COUNT(*) > ?", 2)).
+		OrderBy("u.name ASC").
+		Limit(10).
+		Offset(20)
+	if sql := pgQ.SQL(); sql != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, sql)
+		t.Error("SQL() failed")
+	}
+	if !reflect.DeepEqual(pgQ.Args(), []any{true, 18, "admin", "user", 2}) {
+		t.Logf("Returns: %v\n", pgQ.Args())
+		t.Error("Args() failed")
+	}
+
+	myExp := `SELECT * FROM users WHERE id > ? LIMIT 18446744073709551615 OFFSET 5`
+	myQ := database.NewSelect().
+		Dialect(database.MySQL).
+		From("users").
+		Where(database.NewQuery().And("id > ?", 1)).
+		Offset(5)
+	if sql := myQ.SQL(); sql != myExp {
+		t.Logf("Expected: %s\nReturns: %s\n", myExp, sql)
+		t.Error("SQL() failed")
+	}
+}
+
+func TestSelectCompound(t *testing.T) {
+	tree := database.NewSelect().
+		Columns("id", "parent_id").
+		From("categories").
+		Where(database.NewQuery().And("id = ?", 1)).
+		UnionAll(database.NewSelect().
+			Columns("c.id", "c.parent_id").
+			From("categories c").
+			Join("tree t", "t.id = c.parent_id"))
+	active := database.NewSelect().
+		Columns("id").
+		From("products").
+		Where(database.NewQuery().And("status = ?", "active"))
+
+	query := database.NewSelect().
+		WithRecursive("tree(id, parent_id)", tree).
+		With("active", active).
+		Columns("p.id").
+		From("products p").
+		Join("tree t", "t.id = p.category_id").
+		Where(database.NewQuery().And("p.price > ?", 10)).
+		Union(database.NewSelect().Columns("id").From("featured").Where(database.NewQuery().And("rank < ?", 5))).
+		Except(database.NewSelect().Columns("product_id").From("hidden").Limit(3)).
+		OrderBy("id").
+		Limit(20)
+
+	exp := `WITH RECURSIVE tree(id, parent_id) AS (SELECT id, parent_id FROM categories WHERE id = $1 UNION ALL SELECT c.id, c.parent_id FROM categories c JOIN tree t ON t.id = c.parent_id), active AS (SELECT id FROM products WHERE status = $2) SELECT p.id FROM products p JOIN tree t ON t.id = p.category_id WHERE p.price > $3 UNION SELECT id FROM featured WHERE rank < $4 EXCEPT (SELECT product_id FROM hidden LIMIT 3) ORDER BY id LIMIT 20`
+	if sql := query.SQL(); sql != exp {
+		t.Logf("Expected: %s\nReturns: %s\n", exp, sql)
+		t.Error("SQL() failed")
+	}
+	if !reflect.DeepEqual(query.Args(), []any{1, "active", 10, 5}) {
+		t.Logf("Returns: %v\n", query.Args())
+		t.Error("Args() failed")
+	}
+}
+
+func TestSelectSearch(t *testing.T) {
+	if q := database.ToTSQuery(`go: "sql" & !pg`); q != "go:* & sql:* & pg:*" {
+		t.Errorf("ToTSQuery() failed, returns %q", q)
+	}
+	if q := database.ToBooleanQuery(`go -sql*`); q != "+go* +sql*" {
+		t.Errorf("ToBooleanQuery() failed, returns %q", q)
+	}
+
+	pg := database.NewSelect().
+		From("posts").
+		Where(database.NewQuery().And("status = ?", "published").AndSearch("english", "go sql", "title", "body").AndSearch("english", "!!", "title")).
+		OrderByRank("english", "go sql", "title", "body").
+		Limit(10)
+	pgExp := `SELECT * FROM posts WHERE status = $1 AND to_tsvector('english', concat_ws(' ', title, body)) @@ to_tsquery('english', $2) ORDER BY ts_rank(to_tsvector('english', concat_ws(' ', title, body)), to_tsquery('english', $3)) DESC LIMIT 10`
+	if sql := pg.SQL(); sql != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, sql)
+		t.Error("SQL() failed")
+	}
+	if !reflect.DeepEqual(pg.Args(), []any{"published", "go:* & sql:*", "go:* & sql:*"}) {
+		t.Logf("Returns: %v\n", pg.Args())
+		t.Error("Args() failed")
+	}
+
+	// dialect set after helpers, resolved on render
+	my := database.NewSelect().
+		From("posts").
+		Where(database.NewQuery().AndSearch("", "go sql", "title", "body")).
+		OrderByRank("", "go sql", "title", "body").
+		Dialect(database.MySQL)
+	myExp := `SELECT * FROM posts WHERE MATCH (title, body) AGAINST (? IN BOOLEAN MODE) ORDER BY MATCH (title, body) AGAINST (? IN BOOLEAN MODE) DESC`
+	if sql := my.SQL(); sql != myExp {
+		t.Logf("Expected: %s\nReturns: %s\n", myExp, sql)
+		t.Error("SQL() failed")
+	}
+}
+
+func TestSelectExecutors(t *testing.T) {
+	db, fake := newFakeDB(func(query string, args []any) ([]string, [][]driver.Value) {
+		return []string{"count"}, [][]driver.Value{{int64(1)}}
+	})
+	query := func(dialect database.Dialect) database.SelectBuilder {
+		return database.NewSelect().
+			Dialect(dialect).
+			Columns("id").
+			From("users").
+			Where(database.NewQuery().And("@active AND age > ?", 18).Replace("@active", "deleted_at IS NULL").Replace("@sort", "name")).
+			OrderBy("@sort").
+			Limit(10)
+	}
+
+	tests := []struct {
+		name string
+		run  func()
+		exp  string
+	}{
+		{"Finder Postgres", func() { database.NewFinder[struct{ Count int64 }](db).Select(query(database.Postgres)).Result() }, `SELECT id FROM users WHERE deleted_at IS NULL AND age > $1 ORDER BY name LIMIT 10`},
+		{"Finder MySQL", func() { database.NewFinder[struct{ Count int64 }](db).Select(query(database.MySQL)).Result() }, `SELECT id FROM users WHERE deleted_at IS NULL AND age > ? ORDER BY name LIMIT 10`},
+		{"Finder NumericArgs", func() {
+			database.NewFinder[struct{ Count int64 }](db).Select(query(database.MySQL)).NumericArgs(true).Result()
+		}, `SELECT id FROM users WHERE deleted_at IS NULL AND age > $1 ORDER BY name LIMIT 10`},
+		{"Counter Postgres", func() { database.NewCounter(db).Select(query(database.Postgres)).Result() }, `SELECT COUNT(*) FROM (SELECT id FROM users WHERE deleted_at IS NULL AND age > $1) AS count_query`},
+		{"Counter MySQL", func() { database.NewCounter(db).Select(query(database.MySQL)).Result() }, `SELECT COUNT(*) FROM (SELECT id FROM users WHERE deleted_at IS NULL AND age > ?) AS count_query`},
+	}
+	for _, test := range tests {
+		test.run()
+		if sql, _ := fake.last(); sql != test.exp {
+			t.Logf("Expected: %s\nReturns: %s\n", test.exp, sql)
+			t.Errorf("%s failed", test.name)
+		}
+	}
+}
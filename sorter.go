@@ -0,0 +1,40 @@
+package database
+
+import "errors"
+
+// ErrInvalidSort returned when sort string contains unknown field or invalid modifier
+var ErrInvalidSort = errors.New("invalid sort")
+
+// Sorter safe order by generator from user input
+//
+// sort string is comma separated list of `[+|-]field[:modifier...]` items,
+// - prefix sort descending and modifiers are asc, desc, nulls_first and nulls_last,
+// e.g. "-created_at:nulls_last,name"
+type Sorter interface {
+	// Dialect set sql dialect, Postgres by default
+	//
+	// mysql not support NULLS FIRST/LAST and emulated with `expr IS NULL` order
+	Dialect(dialect Dialect) Sorter
+	// Default set sort string used when parsed input is empty
+	Default(sort string) Sorter
+	// Parse parse and validate sort string against allowed fields
+	//
+	// returns error wrapping ErrInvalidSort on unknown field, duplicated field or invalid modifier
+	Parse(sort string) error
+	// Columns get list of order by columns, e.g. ["created_at DESC", "name ASC"]
+	Columns() []string
+	// Raw get comma separated order by columns
+	Raw() string
+	// SQL get `ORDER BY Raw()` or empty string if no sort parsed
+	SQL() string
+}
+
+// NewSorter generate new sorter with allowed fields map of api name to sql expression
+//
+// sql expressions used as is and must not contains user input
+func NewSorter(fields map[string]string) Sorter {
+	res := new(sorterDriver)
+	res.dialect = Postgres
+	res.fields = fields
+	return res
+}
@@ -0,0 +1,133 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+)
+
+type sortItem struct {
+	Expr  string
+	Desc  bool
+	Nulls string
+}
+
+type sorterDriver struct {
+	dialect Dialect
+	fields  map[string]string
+	def     string
+	items   []sortItem
+}
+
+func (sorter *sorterDriver) Dialect(dialect Dialect) Sorter {
+	sorter.dialect = dialect
+	return sorter
+}
+
+func (sorter *sorterDriver) Default(sort string) Sorter {
+	sorter.def = sort
+	return sorter
+}
+
+func (sorter *sorterDriver) Parse(sort string) error {
+	if strings.TrimSpace(sort) == "" {
+		sort = sorter.def
+	}
+
+	items := make([]sortItem, 0)
+	visited := make(map[string]bool)
+	for _, part := range strings.Split(sort, ",") {
+		// + decoded as space in url query
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		item, name, err := sorter.parseItem(part)
+		if err != nil {
+			return err
+		} else if visited[name] {
+			return fmt.Errorf("%w: duplicated field %q", ErrInvalidSort, name)
+		}
+		visited[name] = true
+		items = append(items, item)
+	}
+
+	sorter.items = items
+	return nil
+}
+
+// parseItem parse single `[+|-]field[:modifier...]` sort item
+func (sorter *sorterDriver) parseItem(part string) (sortItem, string, error) {
+	item := sortItem{}
+	direction := ""
+	if strings.HasPrefix(part, "-") {
+		direction = "desc"
+		part = part[1:]
+	} else if strings.HasPrefix(part, "+") {
+		direction = "asc"
+		part = part[1:]
+	}
+
+	modifiers := strings.Split(part, ":")
+	name := strings.TrimSpace(modifiers[0])
+	expr, ok := sorter.fields[name]
+	if !ok || name == "" {
+		return item, name, fmt.Errorf("%w: unknown field %q", ErrInvalidSort, name)
+	}
+	item.Expr = expr
+
+	for _, modifier := range modifiers[1:] {
+		switch modifier = strings.ToLower(strings.TrimSpace(modifier)); modifier {
+		case "asc", "desc":
+			if direction != "" && direction != modifier {
+				return item, name, fmt.Errorf("%w: conflicting direction for %q", ErrInvalidSort, name)
+			}
+			direction = modifier
+		case "nulls_first", "nulls_last":
+			if item.Nulls != "" && item.Nulls != modifier {
+				return item, name, fmt.Errorf("%w: conflicting nulls order for %q", ErrInvalidSort, name)
+			}
+			item.Nulls = modifier
+		default:
+			return item, name, fmt.Errorf("%w: unknown modifier %q for %q", ErrInvalidSort, modifier, name)
+		}
+	}
+
+	item.Desc = direction == "desc"
+	return item, name, nil
+}
+
+func (sorter *sorterDriver) Columns() []string {
+	res := make([]string, 0, len(sorter.items))
+	for _, item := range sorter.items {
+		direction := "ASC"
+		if item.Desc {
+			direction = "DESC"
+		}
+
+		switch {
+		case item.Nulls == "":
+			res = append(res, item.Expr+" "+direction)
+		case sorter.dialect == MySQL && item.Nulls == "nulls_first":
+			res = append(res, item.Expr+" IS NULL DESC", item.Expr+" "+direction)
+		case sorter.dialect == MySQL:
+			res = append(res, item.Expr+" IS NULL ASC", item.Expr+" "+direction)
+		case item.Nulls == "nulls_first":
+			res = append(res, item.Expr+" "+direction+" NULLS FIRST")
+		default:
+			res = append(res, item.Expr+" "+direction+" NULLS LAST")
+		}
+	}
+	return res
+}
+
+func (sorter *sorterDriver) Raw() string {
+	return strings.Join(sorter.Columns(), ", ")
+}
+
+func (sorter *sorterDriver) SQL() string {
+	if raw := sorter.Raw(); raw != "" {
+		return "ORDER BY " + raw
+	}
+	return ""
+}
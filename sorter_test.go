@@ -0,0 +1,47 @@
+package database_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/gomig/database/v2"
+)
+
+func TestSorter(t *testing.T) {
+	fields := map[string]string{
+		"name":       "u.name",
+		"created_at": "u.created_at",
+		"score":      "COALESCE(s.score, 0)",
+	}
+
+	pg := database.NewSorter(fields)
+	if err := pg.Parse("-created_at:nulls_last, name,+score"); err != nil {
+		t.Fatal(err)
+	}
+	pgExp := `ORDER BY u.created_at DESC NULLS LAST, u.name ASC, COALESCE(s.score, 0) ASC`
+	if sql := pg.SQL(); sql != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, sql)
+		t.Error("SQL() failed")
+	}
+
+	my := database.NewSorter(fields).Dialect(database.MySQL).Default("name:nulls_first")
+	if err := my.Parse(""); err != nil {
+		t.Fatal(err)
+	}
+	myExp := []string{"u.name IS NULL DESC", "u.name ASC"}
+	if !reflect.DeepEqual(my.Columns(), myExp) {
+		t.Logf("Expected: %v\nReturns: %v\n", myExp, my.Columns())
+		t.Error("Columns() failed")
+	}
+
+	for _, sort := range []string{"password", "name;DROP TABLE users", "-name:asc", "name:up", "name,-name"} {
+		if err := database.NewSorter(fields).Parse(sort); !errors.Is(err, database.ErrInvalidSort) {
+			t.Errorf("Parse(%q) must return ErrInvalidSort", sort)
+		}
+	}
+
+	if sql := database.NewSorter(fields).SQL(); sql != "" {
+		t.Errorf("SQL() of empty sorter must be empty, returns %q", sql)
+	}
+}
@@ -0,0 +1,37 @@
+package database
+
+// Statement sql statement builder executable by Commander
+type Statement interface {
+	// SQL get generated sql with dialect placeholder
+	SQL() string
+	// Args get list of arguments
+	Args() []any
+}
+
+// renderStatement get statement sql with normal (?) placeholder and arguments
+func renderStatement(stmt Statement) (string, []any) {
+	switch s := stmt.(type) {
+	case *sBuilder:
+		return s.render()
+	case *isBuilder:
+		return s.render()
+	case *uBuilder:
+		return s.render()
+	default:
+		return stmt.SQL(), stmt.Args()
+	}
+}
+
+// statementDialect get dialect of package statement builders
+func statementDialect(stmt Statement) (Dialect, bool) {
+	switch s := stmt.(type) {
+	case *sBuilder:
+		return s.dialect, true
+	case *isBuilder:
+		return s.dialect, true
+	case *uBuilder:
+		return s.dialect, true
+	default:
+		return Postgres, false
+	}
+}
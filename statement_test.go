@@ -0,0 +1,89 @@
+package database_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gomig/database/v2"
+)
+
+func TestInsertSelect(t *testing.T) {
+	stmt := database.NewInsertSelect().
+		Into("archived_users", "id", "name").
+		Select(database.NewSelect().
+			Columns("id", "name").
+			From("users").
+			Where(database.NewQuery().And("deleted_at < ?", "2024-01-01")))
+
+	db, fake := newFakeDB(nil)
+	if _, err := database.NewCMD(db).Statement(stmt).Exec(); err != nil {
+		t.Fatal(err)
+	}
+	exp := `INSERT INTO archived_users ("id", "name") SELECT id, name FROM users WHERE deleted_at < $1`
+	sql, args := fake.last()
+	if sql != exp {
+		t.Logf("Expected: %s\nReturns: %s\n", exp, sql)
+		t.Error("Statement() failed")
+	}
+	if !reflect.DeepEqual(args, []any{"2024-01-01"}) {
+		t.Logf("Returns: %v\n", args)
+		t.Error("Exec() args failed")
+	}
+}
+
+func TestUpdateBuilder(t *testing.T) {
+	update := func(dialect database.Dialect) database.UpdateBuilder {
+		return database.NewUpdate().
+			Dialect(dialect).
+			Table("users u").
+			SetRaw("total", "o.total + ?", 1).
+			Set("rank", database.NewSelect().Columns("MAX(rank)").From("ranks").Where(database.NewQuery().And("level = ?", 2))).
+			From("orders o").
+			Where(database.NewQuery().And("o.user_id = u.id").And("o.status = ?", "paid"))
+	}
+
+	pgExp := `UPDATE users u SET "total" = o.total + $1, "rank" = (SELECT MAX(rank) FROM ranks WHERE level = $2) FROM orders o WHERE o.user_id = u.id AND o.status = $3`
+	if sql := update(database.Postgres).SQL(); sql != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, sql)
+		t.Error("SQL() failed")
+	}
+
+	myExp := "UPDATE users u, orders o SET `total` = o.total + ?, `rank` = (SELECT MAX(rank) FROM ranks WHERE level = ?) WHERE o.user_id = u.id AND o.status = ?"
+	my := update(database.MySQL)
+	if sql := my.SQL(); sql != myExp {
+		t.Logf("Expected: %s\nReturns: %s\n", myExp, sql)
+		t.Error("SQL() failed")
+	}
+	if !reflect.DeepEqual(my.Args(), []any{1, 2, "paid"}) {
+		t.Logf("Returns: %v\n", my.Args())
+		t.Error("Args() failed")
+	}
+	db, fake := newFakeDB(nil)
+	database.NewCMD(db).Statement(my).Exec(5)
+	if sql, args := fake.last(); sql != myExp || !reflect.DeepEqual(args, []any{int64(1), int64(2), "paid", int64(5)}) {
+		t.Logf("Expected: %s\nReturns: %s %v\n", myExp, sql, args)
+		t.Error("Statement() MySQL failed")
+	}
+
+	database.NewCMD(db).Statement(update(database.Postgres)).Exec()
+	if sql, _ := fake.last(); sql != pgExp {
+		t.Logf("Expected: %s\nReturns: %s\n", pgExp, sql)
+		t.Error("Statement() Postgres failed")
+	}
+
+	database.NewCMD(db).Statement(my).NumericArgs(true).Exec()
+	numericExp := "UPDATE users u, orders o SET `total` = o.total + $1, `rank` = (SELECT MAX(rank) FROM ranks WHERE level = $2) WHERE o.user_id = u.id AND o.status = $3"
+	if sql, _ := fake.last(); sql != numericExp {
+		t.Logf("Expected: %s\nReturns: %s\n", numericExp, sql)
+		t.Error("Statement() NumericArgs override failed")
+	}
+
+	replaced := database.NewUpdate().
+		Table("users").
+		SetRaw("status", "?", "active").
+		Where(database.NewQuery().And("@pending").Replace("@pending", "status = 'pending'"))
+	if exp := `UPDATE users SET "status" = $1 WHERE status = 'pending'`; replaced.SQL() != exp {
+		t.Logf("Expected: %s\nReturns: %s\n", exp, replaced.SQL())
+		t.Error("SQL() where replacements failed")
+	}
+}
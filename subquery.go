@@ -0,0 +1,65 @@
+package database
+
+// isSubquery check if argument is QueryBuilder or SelectBuilder
+func isSubquery(arg any) bool {
+	switch arg.(type) {
+	case SelectBuilder, QueryBuilder:
+		return true
+	default:
+		return false
+	}
+}
+
+// subquery render QueryBuilder or SelectBuilder argument with normal (?) placeholder
+//
+// QueryBuilder argument rendered with dialect of parent, SelectBuilder with own dialect
+func subquery(arg any, dialect Dialect) (string, []any, bool) {
+	switch sub := arg.(type) {
+	case SelectBuilder:
+		query, args := renderSelect(sub)
+		return query, args, true
+	case QueryBuilder:
+		query, args := renderQuery(sub, dialect)
+		return query, args, true
+	default:
+		return "", nil, false
+	}
+}
+
+// renderSubqueries replace placeholder of QueryBuilder and SelectBuilder arguments with (subquery)
+// and merge subquery arguments in placeholder order
+//
+// placeholders resolved by replacePlaceholders, same as numbering and interpolation, so quoted ? ignored
+func renderSubqueries(query string, args []any, dialect Dialect) (string, []any) {
+	found := false
+	for _, arg := range args {
+		if isSubquery(arg) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return query, args
+	}
+
+	result := make([]any, 0, len(args))
+	used := 0
+	query = replacePlaceholders(query, false, func(index int) string {
+		used = index + 1
+		if index >= len(args) {
+			return "?"
+		}
+		if sql, subArgs, ok := subquery(args[index], dialect); ok {
+			result = append(result, subArgs...)
+			return "(" + sql + ")"
+		}
+		result = append(result, args[index])
+		return "?"
+	})
+
+	// keep extra arguments
+	if used < len(args) {
+		result = append(result, args[used:]...)
+	}
+	return query, result
+}
@@ -1,66 +1,52 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullBool nullable string keeper
+// NullBool nullable bool keeper, field based wrapper of Null[bool]
 type NullBool struct {
 	Bool  bool
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullBool) null() Null[bool] {
+	return Null[bool]{V: me.Bool, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
-func (nb *NullBool) Scan(value any) error {
-	nb.Bool, nb.Valid = false, false
-	if value != nil {
-		temp := sql.NullBool{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		nb.Bool, nb.Valid = temp.Bool, temp.Valid
-	}
-	return nil
+func (me *NullBool) Scan(value any) error {
+	temp := Null[bool]{}
+	err := temp.Scan(value)
+	me.Bool, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
-func (nb *NullBool) Value() (driver.Value, error) {
-	if !nb.Valid {
+func (me *NullBool) Value() (driver.Value, error) {
+	if !me.Valid {
 		return nil, nil
 	}
-	return nb.Bool, nil
+	return me.Bool, nil
 }
 
 // Val get nullable value
-func (nb *NullBool) Val() any {
-	if !nb.Valid {
-		return nil
-	}
-	return nb.Bool
+func (me *NullBool) Val() any {
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
-func (nb NullBool) MarshalJSON() ([]byte, error) {
-	if nb.Valid {
-		return json.Marshal(nb.Bool)
-	}
-	return json.Marshal(nil)
+func (me NullBool) MarshalJSON() ([]byte, error) {
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
-func (nb *NullBool) UnmarshalJSON(data []byte) error {
-	var v *bool
-	if err := json.Unmarshal(data, &v); err != nil {
+func (me *NullBool) UnmarshalJSON(data []byte) error {
+	temp := Null[bool]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		nb.Valid = true
-		nb.Bool = *v
-	} else {
-		nb.Valid = false
-	}
+	me.Bool, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullFloat32 nullable string keeper
+// NullFloat32 nullable float32 keeper, field based wrapper of Null[float32]
 type NullFloat32 struct {
 	Float32 float32
 	Valid   bool
 }
 
+// null get generic nullable of value
+func (me NullFloat32) null() Null[float32] {
+	return Null[float32]{V: me.Float32, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of float32 range
 func (me *NullFloat32) Scan(value any) error {
-	me.Float32, me.Valid = 0.0, false
-	if value != nil {
-		temp := sql.NullFloat64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Float32, me.Valid = float32(temp.Float64), temp.Valid
-	}
-	return nil
+	temp := Null[float32]{}
+	err := temp.Scan(value)
+	me.Float32, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullFloat32) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullFloat32) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Float32
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullFloat32) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Float32)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullFloat32) UnmarshalJSON(data []byte) error {
-	var v *float32
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[float32]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Float32 = *v
-	} else {
-		me.Valid = false
-	}
+	me.Float32, me.Valid = temp.V, temp.Valid
 	return nil
 }
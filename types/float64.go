@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullFloat64 nullable string keeper
+// NullFloat64 nullable float64 keeper, field based wrapper of Null[float64]
 type NullFloat64 struct {
 	Float64 float64
 	Valid   bool
 }
 
+// null get generic nullable of value
+func (me NullFloat64) null() Null[float64] {
+	return Null[float64]{V: me.Float64, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of float64 range
 func (me *NullFloat64) Scan(value any) error {
-	me.Float64, me.Valid = 0.0, false
-	if value != nil {
-		temp := sql.NullFloat64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Float64, me.Valid = temp.Float64, temp.Valid
-	}
-	return nil
+	temp := Null[float64]{}
+	err := temp.Scan(value)
+	me.Float64, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullFloat64) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullFloat64) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Float64
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullFloat64) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Float64)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullFloat64) UnmarshalJSON(data []byte) error {
-	var v *float64
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[float64]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Float64 = *v
-	} else {
-		me.Valid = false
-	}
+	me.Float64, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullInt nullable string keeper
+// NullInt nullable int keeper, field based wrapper of Null[int]
 type NullInt struct {
 	Int   int
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullInt) null() Null[int] {
+	return Null[int]{V: me.Int, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of int range
 func (me *NullInt) Scan(value any) error {
-	me.Int, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Int, me.Valid = int(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[int]{}
+	err := temp.Scan(value)
+	me.Int, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullInt) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullInt) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Int
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullInt) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Int)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullInt) UnmarshalJSON(data []byte) error {
-	var v *int
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[int]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Int = *v
-	} else {
-		me.Valid = false
-	}
+	me.Int, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullInt16 nullable string keeper
+// NullInt16 nullable int16 keeper, field based wrapper of Null[int16]
 type NullInt16 struct {
 	Int16 int16
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullInt16) null() Null[int16] {
+	return Null[int16]{V: me.Int16, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of int16 range
 func (me *NullInt16) Scan(value any) error {
-	me.Int16, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Int16, me.Valid = int16(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[int16]{}
+	err := temp.Scan(value)
+	me.Int16, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullInt16) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullInt16) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Int16
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullInt16) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Int16)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullInt16) UnmarshalJSON(data []byte) error {
-	var v *int16
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[int16]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Int16 = *v
-	} else {
-		me.Valid = false
-	}
+	me.Int16, me.Valid = temp.V, temp.Valid
 	return nil
 }
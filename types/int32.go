@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullInt32 nullable string keeper
+// NullInt32 nullable int32 keeper, field based wrapper of Null[int32]
 type NullInt32 struct {
 	Int32 int32
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullInt32) null() Null[int32] {
+	return Null[int32]{V: me.Int32, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of int32 range
 func (me *NullInt32) Scan(value any) error {
-	me.Int32, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Int32, me.Valid = int32(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[int32]{}
+	err := temp.Scan(value)
+	me.Int32, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullInt32) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullInt32) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Int32
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullInt32) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Int32)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullInt32) UnmarshalJSON(data []byte) error {
-	var v *int32
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[int32]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Int32 = *v
-	} else {
-		me.Valid = false
-	}
+	me.Int32, me.Valid = temp.V, temp.Valid
 	return nil
 }
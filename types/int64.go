@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullInt64 nullable string keeper
+// NullInt64 nullable int64 keeper, field based wrapper of Null[int64]
 type NullInt64 struct {
 	Int64 int64
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullInt64) null() Null[int64] {
+	return Null[int64]{V: me.Int64, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of int64 range
 func (me *NullInt64) Scan(value any) error {
-	me.Int64, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Int64, me.Valid = temp.Int64, temp.Valid
-	}
-	return nil
+	temp := Null[int64]{}
+	err := temp.Scan(value)
+	me.Int64, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullInt64) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullInt64) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Int64
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullInt64) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Int64)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullInt64) UnmarshalJSON(data []byte) error {
-	var v *int64
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[int64]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Int64 = *v
-	} else {
-		me.Valid = false
-	}
+	me.Int64, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullInt8 nullable string keeper
+// NullInt8 nullable int8 keeper, field based wrapper of Null[int8]
 type NullInt8 struct {
 	Int8  int8
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullInt8) null() Null[int8] {
+	return Null[int8]{V: me.Int8, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of int8 range
 func (me *NullInt8) Scan(value any) error {
-	me.Int8, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Int8, me.Valid = int8(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[int8]{}
+	err := temp.Scan(value)
+	me.Int8, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullInt8) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullInt8) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Int8
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullInt8) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Int8)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullInt8) UnmarshalJSON(data []byte) error {
-	var v *int8
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[int8]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Int8 = *v
-	} else {
-		me.Valid = false
-	}
+	me.Int8, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -0,0 +1,92 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// JSON nullable json column keeper for arbitrary value
+//
+// value marshaled to json string on write and unmarshaled from []byte or string on read,
+// sql NULL, json null and empty value are null
+type JSON[T any] struct {
+	V     T
+	Valid bool
+}
+
+// JSONOf create valid json of value
+func JSONOf[T any](v T) JSON[T] {
+	return JSON[T]{V: v, Valid: true}
+}
+
+// Scan implements the Scanner interface.
+//
+// empty []byte or string (e.g. empty text column) scanned as null
+func (me *JSON[T]) Scan(value any) error {
+	var zero T
+	me.V, me.Valid = zero, false
+
+	var data []byte
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("converting %T to json not supported", value)
+	}
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil
+	}
+	return me.UnmarshalJSON(data)
+}
+
+// Value implements the driver Valuer interface.
+func (me JSON[T]) Value() (driver.Value, error) {
+	if !me.Valid {
+		return nil, nil
+	}
+	data, err := json.Marshal(me.V)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Val get nullable value
+func (me JSON[T]) Val() any {
+	if !me.Valid {
+		return nil
+	}
+	return me.V
+}
+
+// MarshalJSON convert to json, value encoded as native json
+func (me JSON[T]) MarshalJSON() ([]byte, error) {
+	if me.Valid {
+		return json.Marshal(me.V)
+	}
+	return json.Marshal(nil)
+}
+
+// UnmarshalJSON parse from json
+func (me *JSON[T]) UnmarshalJSON(data []byte) error {
+	var v *T
+	if err := json.Unmarshal(data, &v); err != nil {
+		var zero T
+		me.V, me.Valid = zero, false
+		return err
+	}
+	if v != nil {
+		me.Valid = true
+		me.V = *v
+	} else {
+		var zero T
+		me.V, me.Valid = zero, false
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gomig/database/v2/types"
+)
+
+func TestJSON(t *testing.T) {
+	type settings struct {
+		Theme string   `json:"theme"`
+		Tags  []string `json:"tags"`
+	}
+
+	var s types.JSON[settings]
+	if err := s.Scan([]byte(`{"theme":"dark","tags":["a"]}`)); err != nil || !s.Valid || s.V.Theme != "dark" {
+		t.Errorf("Scan() []byte failed, returns %v %v", s, err)
+	}
+	if err := s.Scan(`{"theme":"light"}`); err != nil || s.V.Theme != "light" || s.V.Tags != nil {
+		t.Errorf("Scan() string failed, returns %v %v", s, err)
+	}
+	if err := s.Scan(nil); err != nil || s.Valid {
+		t.Error("Scan() null failed")
+	}
+	for _, empty := range []any{[]byte{}, "", "  "} {
+		s = types.JSONOf(settings{Theme: "dark"})
+		if err := s.Scan(empty); err != nil || s.Valid || s.V.Theme != "" {
+			t.Errorf("Scan() empty %q failed, returns %v %v", empty, s, err)
+		}
+	}
+	if err := s.Scan(42); err == nil || s.Valid {
+		t.Error("Scan() must fail on unsupported type")
+	}
+	if v, err := s.Value(); err != nil || v != nil {
+		t.Errorf("Value() null failed, returns %v %v", v, err)
+	}
+
+	s = types.JSONOf(settings{Theme: "dark"})
+	if v, err := s.Value(); err != nil || v != `{"theme":"dark","tags":null}` {
+		t.Errorf("Value() failed, returns %v %v", v, err)
+	}
+
+	data, _ := json.Marshal(map[string]any{"settings": s})
+	if string(data) != `{"settings":{"theme":"dark","tags":null}}` {
+		t.Errorf("MarshalJSON() failed, returns %s", data)
+	}
+}
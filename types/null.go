@@ -0,0 +1,275 @@
+package types
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// Null generic nullable value keeper
+//
+// T can be string, bool, numeric, time.Time, []byte, types based on them
+// or types implementing sql.Scanner and driver.Valuer
+type Null[T any] struct {
+	V     T
+	Valid bool
+}
+
+// NullOf create valid nullable of value
+func NullOf[T any](v T) Null[T] {
+	return Null[T]{V: v, Valid: true}
+}
+
+// NullFrom create nullable from pointer, nil pointer is null
+func NullFrom[T any](ptr *T) Null[T] {
+	if ptr == nil {
+		return Null[T]{}
+	}
+	return NullOf(*ptr)
+}
+
+// Scan implements the Scanner interface.
+func (me *Null[T]) Scan(value any) error {
+	var zero T
+	me.V, me.Valid = zero, false
+	if value == nil {
+		return nil
+	}
+
+	if scanner, ok := any(&me.V).(sql.Scanner); ok {
+		if err := scanner.Scan(value); err != nil {
+			me.V = zero
+			return err
+		}
+		me.Valid = true
+		return nil
+	}
+
+	if err := scanNull(reflect.ValueOf(&me.V).Elem(), value); err != nil {
+		me.V = zero
+		return err
+	}
+	me.Valid = true
+	return nil
+}
+
+// scanNull scan database value into target by target kind
+func scanNull(target reflect.Value, value any) error {
+	if src := reflect.ValueOf(value); target.Kind() != reflect.Slice && src.Type().AssignableTo(target.Type()) {
+		target.Set(src)
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		temp := sql.NullString{}
+		if err := temp.Scan(value); err != nil {
+			return err
+		}
+		target.SetString(temp.String)
+	case reflect.Bool:
+		temp := sql.NullBool{}
+		if err := temp.Scan(value); err != nil {
+			return err
+		}
+		target.SetBool(temp.Bool)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		temp := sql.NullInt64{}
+		if err := temp.Scan(value); err != nil {
+			return err
+		}
+		if target.OverflowInt(temp.Int64) {
+			return fmt.Errorf("value %d overflows %s", temp.Int64, target.Type())
+		}
+		target.SetInt(temp.Int64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		temp := sql.NullString{}
+		if err := temp.Scan(value); err != nil {
+			return err
+		}
+		v, err := strconv.ParseUint(temp.String, 10, target.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("converting %q to %s: %w", temp.String, target.Type(), err)
+		}
+		target.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		temp := sql.NullFloat64{}
+		if err := temp.Scan(value); err != nil {
+			return err
+		}
+		if target.OverflowFloat(temp.Float64) {
+			return fmt.Errorf("value %g overflows %s", temp.Float64, target.Type())
+		}
+		target.SetFloat(temp.Float64)
+	case reflect.Slice:
+		if target.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("unsupported scan type %s", target.Type())
+		}
+		// copy bytes, driver may reuse buffer
+		switch v := value.(type) {
+		case []byte:
+			target.SetBytes(append([]byte{}, v...))
+		case string:
+			target.SetBytes([]byte(v))
+		default:
+			return fmt.Errorf("converting %T to %s not supported", value, target.Type())
+		}
+	default:
+		if target.Type() == reflect.TypeOf(time.Time{}) {
+			temp := sql.NullTime{}
+			if err := temp.Scan(value); err != nil {
+				return err
+			}
+			target.Set(reflect.ValueOf(temp.Time))
+			return nil
+		}
+		return fmt.Errorf("unsupported scan type %s", target.Type())
+	}
+	return nil
+}
+
+// Value implements the driver Valuer interface.
+func (me Null[T]) Value() (driver.Value, error) {
+	if !me.Valid {
+		return nil, nil
+	}
+	if valuer, ok := any(me.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	if valuer, ok := any(&me.V).(driver.Valuer); ok {
+		return valuer.Value()
+	}
+	return driver.DefaultParameterConverter.ConvertValue(me.V)
+}
+
+// Val get nullable value
+func (me Null[T]) Val() any {
+	if !me.Valid {
+		return nil
+	}
+	return me.V
+}
+
+// Ptr get pointer to copy of value, nil if null
+func (me Null[T]) Ptr() *T {
+	if !me.Valid {
+		return nil
+	}
+	v := me.V
+	return &v
+}
+
+// ValueOr get value or def if null
+func (me Null[T]) ValueOr(def T) T {
+	if !me.Valid {
+		return def
+	}
+	return me.V
+}
+
+// MarshalJSON convert to json
+func (me Null[T]) MarshalJSON() ([]byte, error) {
+	if me.Valid {
+		return json.Marshal(me.V)
+	}
+	return json.Marshal(nil)
+}
+
+// UnmarshalJSON parse from json
+func (me *Null[T]) UnmarshalJSON(data []byte) error {
+	var v *T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v != nil {
+		me.Valid = true
+		me.V = *v
+	} else {
+		var zero T
+		me.V, me.Valid = zero, false
+	}
+	return nil
+}
+
+// MarshalText convert to text, null converted to empty text
+func (me Null[T]) MarshalText() ([]byte, error) {
+	if !me.Valid {
+		return []byte{}, nil
+	}
+	if marshaler, ok := any(me.V).(encoding.TextMarshaler); ok {
+		return marshaler.MarshalText()
+	}
+
+	val := reflect.ValueOf(me.V)
+	switch val.Kind() {
+	case reflect.String:
+		return []byte(val.String()), nil
+	case reflect.Bool:
+		return []byte(strconv.FormatBool(val.Bool())), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return []byte(strconv.FormatInt(val.Int(), 10)), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return []byte(strconv.FormatUint(val.Uint(), 10)), nil
+	case reflect.Float32, reflect.Float64:
+		return []byte(strconv.FormatFloat(val.Float(), 'g', -1, val.Type().Bits())), nil
+	default:
+		return nil, fmt.Errorf("unsupported text type %s", val.Type())
+	}
+}
+
+// UnmarshalText parse from text, empty text parsed as null
+func (me *Null[T]) UnmarshalText(text []byte) error {
+	var zero T
+	me.V, me.Valid = zero, false
+	if len(text) == 0 {
+		return nil
+	}
+	if unmarshaler, ok := any(&me.V).(encoding.TextUnmarshaler); ok {
+		if err := unmarshaler.UnmarshalText(text); err != nil {
+			me.V = zero
+			return err
+		}
+		me.Valid = true
+		return nil
+	}
+
+	target := reflect.ValueOf(&me.V).Elem()
+	str := string(text)
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(str)
+	case reflect.Bool:
+		v, err := strconv.ParseBool(str)
+		if err != nil {
+			return err
+		}
+		target.SetBool(v)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(str, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetInt(v)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v, err := strconv.ParseUint(str, 10, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetUint(v)
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(str, target.Type().Bits())
+		if err != nil {
+			return err
+		}
+		target.SetFloat(v)
+	default:
+		return fmt.Errorf("unsupported text type %s", target.Type())
+	}
+	me.Valid = true
+	return nil
+}
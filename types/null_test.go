@@ -0,0 +1,95 @@
+package types_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/gomig/database/v2/types"
+)
+
+func TestNull(t *testing.T) {
+	var n types.Null[int8]
+	if err := n.Scan(int64(12)); err != nil || !n.Valid || n.V != 12 {
+		t.Errorf("Scan() failed, returns %v %v", n, err)
+	}
+	if err := n.Scan(int64(300)); err == nil || n.Valid {
+		t.Error("Scan() must fail on overflow")
+	}
+	if err := n.Scan(nil); err != nil || n.Valid || n.ValueOr(5) != 5 || n.Ptr() != nil {
+		t.Error("Scan() null failed")
+	}
+
+	var u types.Null[uint64]
+	if err := u.Scan([]byte("18446744073709551615")); err != nil || u.V != 18446744073709551615 {
+		t.Errorf("Scan() uint64 failed, returns %v %v", u, err)
+	}
+
+	s := types.NullFrom[string](nil)
+	if err := json.Unmarshal([]byte(`"john"`), &s); err != nil || !s.Valid || *s.Ptr() != "john" {
+		t.Errorf("UnmarshalJSON() failed, returns %v %v", s, err)
+	}
+	if v, err := s.Value(); err != nil || v != "john" {
+		t.Errorf("Value() failed, returns %v %v", v, err)
+	}
+
+	type payload struct {
+		Age  types.Null[int]       `json:"age"`
+		Time types.Null[time.Time] `json:"time"`
+	}
+	data, _ := json.Marshal(payload{Age: types.NullOf(30)})
+	if string(data) != `{"age":30,"time":null}` {
+		t.Errorf("MarshalJSON() failed, returns %s", data)
+	}
+
+	var f types.Null[float64]
+	if err := f.UnmarshalText([]byte("1.5")); err != nil || f.V != 1.5 {
+		t.Errorf("UnmarshalText() failed, returns %v %v", f, err)
+	}
+	if text, _ := f.MarshalText(); string(text) != "1.5" {
+		t.Errorf("MarshalText() failed, returns %s", text)
+	}
+}
+
+func TestLegacyNull(t *testing.T) {
+	var i types.NullInt8
+	if err := i.Scan(int64(12)); err != nil || !i.Valid || i.Int8 != 12 {
+		t.Errorf("NullInt8.Scan() failed, returns %v %v", i, err)
+	}
+	if err := i.Scan(int64(300)); err == nil || i.Valid || i.Int8 != 0 {
+		t.Error("NullInt8.Scan() must fail on overflow")
+	}
+
+	var u types.NullUInt64
+	if err := u.Scan([]byte("18446744073709551615")); err != nil || u.UInt64 != 18446744073709551615 {
+		t.Errorf("NullUInt64.Scan() failed, returns %v %v", u, err)
+	}
+	if err := u.Scan(int64(-1)); err == nil || u.Valid {
+		t.Error("NullUInt64.Scan() must fail on negative value")
+	}
+
+	s := types.NullString{String: "john", Valid: true}
+	if v, err := s.Value(); err != nil || v != "john" || s.Val() != "john" {
+		t.Errorf("NullString.Value() failed, returns %v %v", v, err)
+	}
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var tm types.NullTime
+	if err := tm.Scan(now); err != nil || !tm.Valid || !tm.Time.Equal(now) {
+		t.Errorf("NullTime.Scan() failed, returns %v %v", tm, err)
+	}
+
+	type payload struct {
+		Age    types.NullInt     `json:"age"`
+		Active types.NullBool    `json:"active"`
+		Score  types.NullFloat32 `json:"score"`
+	}
+	var p payload
+	if err := json.Unmarshal([]byte(`{"age":30,"active":null,"score":1.5}`), &p); err != nil ||
+		p.Age.Int != 30 || !p.Age.Valid || p.Active.Valid || p.Score.Float32 != 1.5 {
+		t.Errorf("UnmarshalJSON() failed, returns %v %v", p, err)
+	}
+	if data, _ := json.Marshal(p); string(data) != `{"age":30,"active":null,"score":1.5}` {
+		t.Errorf("MarshalJSON() failed, returns %s", data)
+	}
+}
@@ -1,29 +1,26 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullString nullable string keeper
+// NullString nullable string keeper, field based wrapper of Null[string]
 type NullString struct {
 	String string
 	Valid  bool
 }
 
+// null get generic nullable of value
+func (me NullString) null() Null[string] {
+	return Null[string]{V: me.String, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
 func (me *NullString) Scan(value any) error {
-	me.String, me.Valid = "", false
-	if value != nil {
-		temp := sql.NullString{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.String, me.Valid = temp.String, temp.Valid
-	}
-	return nil
+	temp := Null[string]{}
+	err := temp.Scan(value)
+	me.String, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +33,20 @@ func (me *NullString) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullString) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.String
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullString) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.String)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullString) UnmarshalJSON(data []byte) error {
-	var v *string
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[string]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.String = *v
-	} else {
-		me.Valid = false
-	}
+	me.String, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,30 +1,27 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 	"time"
 )
 
-// NullTime nullable string keeper
+// NullTime nullable time.Time keeper, field based wrapper of Null[time.Time]
 type NullTime struct {
 	Time  time.Time
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullTime) null() Null[time.Time] {
+	return Null[time.Time]{V: me.Time, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
 func (me *NullTime) Scan(value any) error {
-	me.Time, me.Valid = time.Time{}, false
-	if value != nil {
-		temp := sql.NullTime{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.Time, me.Valid = temp.Time, temp.Valid
-	}
-	return nil
+	temp := Null[time.Time]{}
+	err := temp.Scan(value)
+	me.Time, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -37,31 +34,20 @@ func (me *NullTime) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullTime) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.Time
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullTime) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.Time)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullTime) UnmarshalJSON(data []byte) error {
-	var v *time.Time
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[time.Time]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.Time = *v
-	} else {
-		me.Valid = false
-	}
+	me.Time, me.Valid = temp.V, temp.Valid
 	return nil
 }
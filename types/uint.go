@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullUInt nullable string keeper
+// NullUInt nullable uint keeper, field based wrapper of Null[uint]
 type NullUInt struct {
 	UInt  uint
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullUInt) null() Null[uint] {
+	return Null[uint]{V: me.UInt, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of uint range
 func (me *NullUInt) Scan(value any) error {
-	me.UInt, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.UInt, me.Valid = uint(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[uint]{}
+	err := temp.Scan(value)
+	me.UInt, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullUInt) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullUInt) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.UInt
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullUInt) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.UInt)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullUInt) UnmarshalJSON(data []byte) error {
-	var v *uint
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[uint]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.UInt = *v
-	} else {
-		me.Valid = false
-	}
+	me.UInt, me.Valid = temp.V, temp.Valid
 	return nil
 }
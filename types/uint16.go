@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullUInt16 nullable string keeper
+// NullUInt16 nullable uint16 keeper, field based wrapper of Null[uint16]
 type NullUInt16 struct {
 	UInt16 uint16
 	Valid  bool
 }
 
+// null get generic nullable of value
+func (me NullUInt16) null() Null[uint16] {
+	return Null[uint16]{V: me.UInt16, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of uint16 range
 func (me *NullUInt16) Scan(value any) error {
-	me.UInt16, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.UInt16, me.Valid = uint16(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[uint16]{}
+	err := temp.Scan(value)
+	me.UInt16, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullUInt16) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullUInt16) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.UInt16
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullUInt16) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.UInt16)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullUInt16) UnmarshalJSON(data []byte) error {
-	var v *uint16
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[uint16]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.UInt16 = *v
-	} else {
-		me.Valid = false
-	}
+	me.UInt16, me.Valid = temp.V, temp.Valid
 	return nil
 }
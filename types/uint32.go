@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullUInt32 nullable string keeper
+// NullUInt32 nullable uint32 keeper, field based wrapper of Null[uint32]
 type NullUInt32 struct {
 	UInt32 uint32
 	Valid  bool
 }
 
+// null get generic nullable of value
+func (me NullUInt32) null() Null[uint32] {
+	return Null[uint32]{V: me.UInt32, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of uint32 range
 func (me *NullUInt32) Scan(value any) error {
-	me.UInt32, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.UInt32, me.Valid = uint32(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[uint32]{}
+	err := temp.Scan(value)
+	me.UInt32, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullUInt32) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullUInt32) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.UInt32
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullUInt32) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.UInt32)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullUInt32) UnmarshalJSON(data []byte) error {
-	var v *uint32
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[uint32]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.UInt32 = *v
-	} else {
-		me.Valid = false
-	}
+	me.UInt32, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullUInt64 nullable string keeper
+// NullUInt64 nullable uint64 keeper, field based wrapper of Null[uint64]
 type NullUInt64 struct {
 	UInt64 uint64
 	Valid  bool
 }
 
+// null get generic nullable of value
+func (me NullUInt64) null() Null[uint64] {
+	return Null[uint64]{V: me.UInt64, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of uint64 range
 func (me *NullUInt64) Scan(value any) error {
-	me.UInt64, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.UInt64, me.Valid = uint64(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[uint64]{}
+	err := temp.Scan(value)
+	me.UInt64, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullUInt64) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullUInt64) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.UInt64
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullUInt64) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.UInt64)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullUInt64) UnmarshalJSON(data []byte) error {
-	var v *uint64
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[uint64]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.UInt64 = *v
-	} else {
-		me.Valid = false
-	}
+	me.UInt64, me.Valid = temp.V, temp.Valid
 	return nil
 }
@@ -1,29 +1,28 @@
 package types
 
 import (
-	"database/sql"
 	"database/sql/driver"
-	"encoding/json"
 )
 
-// NullUInt8 nullable string keeper
+// NullUInt8 nullable uint8 keeper, field based wrapper of Null[uint8]
 type NullUInt8 struct {
 	UInt8 uint8
 	Valid bool
 }
 
+// null get generic nullable of value
+func (me NullUInt8) null() Null[uint8] {
+	return Null[uint8]{V: me.UInt8, Valid: me.Valid}
+}
+
 // Scan implements the Scanner interface.
+//
+// returns error if value out of uint8 range
 func (me *NullUInt8) Scan(value any) error {
-	me.UInt8, me.Valid = 0, false
-	if value != nil {
-		temp := sql.NullInt64{}
-		err := temp.Scan(value)
-		if err != nil {
-			return err
-		}
-		me.UInt8, me.Valid = uint8(temp.Int64), temp.Valid
-	}
-	return nil
+	temp := Null[uint8]{}
+	err := temp.Scan(value)
+	me.UInt8, me.Valid = temp.V, temp.Valid
+	return err
 }
 
 // Value implements the driver Valuer interface.
@@ -36,31 +35,20 @@ func (me *NullUInt8) Value() (driver.Value, error) {
 
 // Val get nullable value
 func (me *NullUInt8) Val() any {
-	if !me.Valid {
-		return nil
-	}
-	return me.UInt8
+	return me.null().Val()
 }
 
 // MarshalJSON convert to json
 func (me NullUInt8) MarshalJSON() ([]byte, error) {
-	if me.Valid {
-		return json.Marshal(me.UInt8)
-	}
-	return json.Marshal(nil)
+	return me.null().MarshalJSON()
 }
 
 // UnmarshalJSON parse from json
 func (me *NullUInt8) UnmarshalJSON(data []byte) error {
-	var v *uint8
-	if err := json.Unmarshal(data, &v); err != nil {
+	temp := Null[uint8]{}
+	if err := temp.UnmarshalJSON(data); err != nil {
 		return err
 	}
-	if v != nil {
-		me.Valid = true
-		me.UInt8 = *v
-	} else {
-		me.Valid = false
-	}
+	me.UInt8, me.Valid = temp.V, temp.Valid
 	return nil
 }
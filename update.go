@@ -0,0 +1,38 @@
+package database
+
+// UpdateBuilder set based UPDATE statement builder
+//
+// Postgres render `UPDATE table SET ... FROM other WHERE ...`
+// and MySQL render `UPDATE table, other SET ... WHERE ...`
+type UpdateBuilder interface {
+	// Dialect set sql dialect, Postgres by default
+	Dialect(dialect Dialect) UpdateBuilder
+	// QuoteFields specifies whether to use quoted column names in SET or not, quoted by default
+	QuoteFields(quoted bool) UpdateBuilder
+	// Table set table to update, e.g. "users u"
+	Table(table string) UpdateBuilder
+	// Set add `column = ?` assignment, QueryBuilder and SelectBuilder value rendered as subquery
+	Set(column string, value any) UpdateBuilder
+	// SetRaw add `column = expression` assignment, e.g. SetRaw("total", "o.total + ?", 1)
+	SetRaw(column string, expr string, args ...any) UpdateBuilder
+	// From add tables to read values from
+	From(tables ...string) UpdateBuilder
+	// Where set where condition
+	Where(query QueryBuilder) UpdateBuilder
+	// SQL get generated sql with dialect placeholder
+	SQL() string
+	// Args get list of arguments
+	Args() []any
+	// Debug get generated sql with arguments quoted for dialect
+	//
+	// result is for logging and debugging only and must not be executed
+	Debug() string
+}
+
+// NewUpdate generate new update statement builder
+func NewUpdate() UpdateBuilder {
+	res := new(uBuilder)
+	res.dialect = Postgres
+	res.quoted = true
+	return res
+}
@@ -0,0 +1,108 @@
+package database
+
+import "strings"
+
+type uSet struct {
+	Column string
+	Expr   string
+	Args   []any
+}
+
+type uBuilder struct {
+	dialect Dialect
+	quoted  bool
+	table   string
+	sets    []uSet
+	tables  []string
+	where   QueryBuilder
+}
+
+func (builder *uBuilder) Dialect(dialect Dialect) UpdateBuilder {
+	builder.dialect = dialect
+	return builder
+}
+
+func (builder *uBuilder) QuoteFields(quoted bool) UpdateBuilder {
+	builder.quoted = quoted
+	return builder
+}
+
+func (builder *uBuilder) Table(table string) UpdateBuilder {
+	builder.table = table
+	return builder
+}
+
+func (builder *uBuilder) Set(column string, value any) UpdateBuilder {
+	builder.sets = append(builder.sets, uSet{column, "?", []any{value}})
+	return builder
+}
+
+func (builder *uBuilder) SetRaw(column string, expr string, args ...any) UpdateBuilder {
+	builder.sets = append(builder.sets, uSet{column, expr, args})
+	return builder
+}
+
+func (builder *uBuilder) From(tables ...string) UpdateBuilder {
+	builder.tables = append(builder.tables, tables...)
+	return builder
+}
+
+func (builder *uBuilder) Where(query QueryBuilder) UpdateBuilder {
+	builder.where = query
+	return builder
+}
+
+// render generate statement with normal (?) placeholder and arguments list
+func (builder *uBuilder) render() (string, []any) {
+	args := make([]any, 0)
+	parts := []string{"UPDATE " + builder.table}
+	if builder.dialect == MySQL && len(builder.tables) > 0 {
+		parts[0] = parts[0] + ", " + strings.Join(builder.tables, ", ")
+	}
+
+	sets := make([]string, 0, len(builder.sets))
+	for _, set := range builder.sets {
+		column := set.Column
+		if builder.quoted {
+			column = builder.dialect.quote(column)
+		}
+		expr, exprArgs := renderSubqueries(set.Expr, set.Args, builder.dialect)
+		sets = append(sets, column+" = "+expr)
+		args = append(args, exprArgs...)
+	}
+	parts = append(parts, "SET "+strings.Join(sets, ", "))
+
+	if builder.dialect != MySQL && len(builder.tables) > 0 {
+		parts = append(parts, "FROM "+strings.Join(builder.tables, ", "))
+	}
+
+	command := strings.Join(parts, " ")
+	if builder.where != nil {
+		if where, whereArgs := renderQuery(builder.where, builder.dialect); where != "" {
+			command = command + " WHERE " + where
+			args = append(args, whereArgs...)
+		}
+		if replacements := queryReplacements(builder.where); len(replacements) > 0 {
+			command = strings.NewReplacer(replacements...).Replace(command)
+		}
+	}
+	return command, args
+}
+
+func (builder *uBuilder) SQL() string {
+	command, _ := builder.render()
+	if builder.dialect.numeric() {
+		command = numericArgs(command, 1)
+	}
+	return command
+}
+
+func (builder *uBuilder) Args() []any {
+	_, args := builder.render()
+	return args
+}
+
+func (builder *uBuilder) Debug() string {
+	command, args := builder.render()
+	return debugComment + interpolate(command, args, builder.dialect)
+}
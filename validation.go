@@ -0,0 +1,140 @@
+package database
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// IValidator called by Inserter and Updater to validate entity before write
+type IValidator interface {
+	Validate() error
+}
+
+// FieldError failed validation rule of struct field
+type FieldError struct {
+	Field  string
+	Column string
+	Rule   string
+	Param  string
+}
+
+func (err FieldError) Error() string {
+	if err.Param == "" {
+		return fmt.Sprintf("%s failed on %s rule", err.Column, err.Rule)
+	}
+	return fmt.Sprintf("%s failed on %s=%s rule", err.Column, err.Rule, err.Param)
+}
+
+// ValidationError list of struct fields failed on `validate` tag rules
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (err *ValidationError) Error() string {
+	messages := make([]string, 0)
+	for _, field := range err.Fields {
+		messages = append(messages, field.Error())
+	}
+	return "validation failed: " + strings.Join(messages, ", ")
+}
+
+// validateStruct validate fields by `validate` tag rules then call IValidator
+//
+// supported rules: required, min=n, max=n, len=n and oneof=a b c
+func validateStruct(val reflect.Value, fields []structField) error {
+	failed := make([]FieldError, 0)
+	for _, field := range fields {
+		tag, ok := val.Type().Field(field.Index).Tag.Lookup("validate")
+		if !ok || tag == "" || tag == "-" {
+			continue
+		}
+
+		value, present := underlyingValue(val.Field(field.Index))
+		for _, rule := range strings.Split(tag, ",") {
+			rule = strings.TrimSpace(rule)
+			name, param, _ := strings.Cut(rule, "=")
+			if valid, err := validateRule(name, param, value, present); err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			} else if !valid {
+				failed = append(failed, FieldError{
+					Field:  field.Name,
+					Column: field.Column,
+					Rule:   name,
+					Param:  param,
+				})
+			}
+		}
+	}
+
+	if len(failed) > 0 {
+		return &ValidationError{Fields: failed}
+	}
+
+	if val.CanAddr() {
+		if validator, ok := val.Addr().Interface().(IValidator); ok {
+			return validator.Validate()
+		}
+	}
+	return nil
+}
+
+// validateRule check single validation rule
+func validateRule(rule, param string, val reflect.Value, present bool) (bool, error) {
+	switch rule {
+	case "required":
+		return present && !val.IsZero(), nil
+	case "min", "max", "len":
+		if !present {
+			return true, nil
+		}
+		limit, err := strconv.ParseFloat(param, 64)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s rule parameter %q", rule, param)
+		}
+		size, ok := validationSize(val)
+		if !ok {
+			return false, fmt.Errorf("%s rule not supported for %s", rule, val.Type())
+		}
+		switch rule {
+		case "min":
+			return size >= limit, nil
+		case "max":
+			return size <= limit, nil
+		default:
+			return size == limit, nil
+		}
+	case "oneof":
+		if !present {
+			return true, nil
+		}
+		for _, option := range strings.Fields(param) {
+			if fmt.Sprint(val.Interface()) == option {
+				return true, nil
+			}
+		}
+		return false, nil
+	default:
+		return false, fmt.Errorf("unknown validation rule %q", rule)
+	}
+}
+
+// validationSize get length of string and collections or numeric value
+func validationSize(val reflect.Value) (float64, bool) {
+	switch val.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(val.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(val.Len()), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(val.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(val.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return val.Float(), true
+	default:
+		return 0, false
+	}
+}